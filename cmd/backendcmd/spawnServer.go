@@ -12,12 +12,16 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var app *application.Avalanche
+var (
+	app *application.Avalanche
+
+	localNetworkName string
+)
 
 // backendCmd is the command to run the backend gRPC process
 func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
 	app = injectedApp
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:    constants.BackendCmd,
 		Short:  "Run the backend server",
 		Long:   "This tool requires a backend process to run; this command starts it",
@@ -25,10 +29,15 @@ func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
 		Args:   cobra.ExactArgs(0),
 		Hidden: true,
 	}
+	cmd.Flags().StringVar(&localNetworkName, constants.LocalNetworkBackendFlag, "", "local network served by the backend")
+	return cmd
 }
 
 func startBackend(_ *cobra.Command, _ []string) error {
-	s, err := binutils.NewGRPCServer(app.GetSnapshotsDir())
+	if localNetworkName != "" {
+		app.UseLocalNetwork(localNetworkName)
+	}
+	s, err := binutils.NewGRPCServer(app)
 	if err != nil {
 		return err
 	}
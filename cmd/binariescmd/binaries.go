@@ -0,0 +1,52 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/spf13/cobra"
+)
+
+var app *application.Avalanche
+
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	app = injectedApp
+
+	cmd := &cobra.Command{
+		Use:   "binaries",
+		Short: "Manage the installed versions of avalanchego and of the VMs",
+		Long: `The binaries command suite manages the versions of avalanchego, Subnet-EVM and
+SpacesVM installed by the CLI. It shows which subnets or running networks use each
+version, installs versions ahead of time, removes the ones no longer needed, and
+populates release mirrors for environments without GitHub access.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	// avalanche binaries list
+	cmd.AddCommand(newListCmd())
+
+	// avalanche binaries install
+	cmd.AddCommand(newInstallCmd())
+
+	// avalanche binaries remove
+	cmd.AddCommand(newRemoveCmd())
+
+	// avalanche binaries prune
+	cmd.AddCommand(newPruneCmd())
+
+	// avalanche binaries which
+	cmd.AddCommand(newWhichCmd())
+
+	// avalanche binaries mirror
+	cmd.AddCommand(newMirrorCmd())
+
+	return cmd
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche binaries install
+func newInstallCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "install [binary] [version]",
+		Short: "Install a version of avalanchego or of a VM",
+		Long: `The binaries install command installs the given version of avalanchego, subnet-evm
+or spacesvm ahead of time, so that later deploys and upgrades don't need to download it.
+Use latest as version to install the latest release.`,
+		RunE:         installBinary,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+	}
+}
+
+func installBinary(_ *cobra.Command, args []string) error {
+	name, version := args[0], args[1]
+	version, err := binutils.InstallBinaryVersion(app, name, version)
+	if err != nil {
+		return err
+	}
+	binary, err := binutils.GetInstalledBinary(app, name, version)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s %s is installed at %s", name, version, binary.Path)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche binaries list
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the installed versions of avalanchego and of the VMs",
+		Long: `The binaries list command prints the installed versions of avalanchego, Subnet-EVM
+and SpacesVM, with their disk size and what references them: the subnets configured to
+use them, including the versions kept for rollbacks, and the running networks.`,
+		RunE:         listBinaries,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func listBinaries(*cobra.Command, []string) error {
+	installed, err := binutils.GetInstalledBinaries(app)
+	if err != nil {
+		return err
+	}
+	if len(installed) == 0 {
+		ux.Logger.PrintToUser("No binaries installed")
+		return nil
+	}
+	references, err := binutils.GetBinaryReferences(app)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Binary", "Version", "Size", "Referenced by"})
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	var total int64
+	for _, binary := range installed {
+		referencedBy := logging.Yellow.Wrap("unused")
+		if len(references[binary.Path]) > 0 {
+			referencedBy = strings.Join(references[binary.Path], "\n")
+		}
+		table.Append([]string{binary.Name, binary.Version, utils.FormatBytes(binary.Size), referencedBy})
+		total += binary.Size
+	}
+	table.Render()
+	ux.Logger.PrintToUser("Total size: %s", utils.FormatBytes(total))
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"path/filepath"
+	"runtime"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mirrorAvagoVersions     []string
+	mirrorSubnetEVMVersions []string
+	mirrorSpacesVMVersions  []string
+	mirrorPlatforms         []string
+)
+
+// avalanche binaries mirror
+func newMirrorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror [dir]",
+		Short: "Populate a release mirror for restricted environments",
+		Long: `The binaries mirror command downloads from GitHub the given versions of avalanchego,
+subnet-evm and spacesvm, their release lists, the compatibility data and the bootstrap
+snapshot into a directory, laid out as a release mirror. Serve the directory over HTTP
+or use it directly by setting release-mirror to its file:// URL in the CLI config file.
+Running the command again on the same directory adds to the mirror.`,
+		RunE:         mirrorBinaries,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&mirrorAvagoVersions, "avalanchego-versions", []string{"latest"}, "avalanchego versions to mirror")
+	cmd.Flags().StringSliceVar(&mirrorSubnetEVMVersions, "subnet-evm-versions", []string{"latest"}, "subnet-evm versions to mirror")
+	cmd.Flags().StringSliceVar(&mirrorSpacesVMVersions, "spacesvm-versions", []string{"latest"}, "spacesvm versions to mirror")
+	cmd.Flags().StringSliceVar(&mirrorPlatforms, "platforms", []string{runtime.GOOS + "/" + runtime.GOARCH}, "os/arch platforms to mirror the release archives of")
+	return cmd
+}
+
+func mirrorBinaries(_ *cobra.Command, args []string) error {
+	mirrorDir, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	versions := map[string][]string{
+		constants.AvalancheGoInstallDir: mirrorAvagoVersions,
+		constants.SubnetEVMInstallDir:   mirrorSubnetEVMVersions,
+		constants.SpacesVMInstallDir:    mirrorSpacesVMVersions,
+	}
+	// the mirror is always populated from GitHub, whatever the configured release mirror
+	if err := binutils.MirrorReleases(app, application.NewDownloader(), mirrorDir, versions, mirrorPlatforms); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Release mirror populated at %s", mirrorDir)
+	ux.Logger.PrintToUser("To use it, set release-mirror to file://%s in the CLI config file,", filepath.ToSlash(mirrorDir))
+	ux.Logger.PrintToUser("or to the URL of an HTTP server serving that directory")
+	return nil
+}
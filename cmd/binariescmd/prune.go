@@ -0,0 +1,42 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche binaries prune
+func newPruneCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove the versions of avalanchego and of the VMs not in use",
+		Long: `The binaries prune command removes the installed versions of avalanchego, Subnet-EVM
+and SpacesVM that are not referenced by any subnet, including the versions kept for
+rollbacks, nor run by any process.`,
+		RunE:         pruneBinaries,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func pruneBinaries(*cobra.Command, []string) error {
+	removed, err := binutils.PruneBinaries(app)
+	var total int64
+	for _, binary := range removed {
+		ux.Logger.PrintToUser("Removed %s %s, reclaimed %s", binary.Name, binary.Version, utils.FormatBytes(binary.Size))
+		total += binary.Size
+	}
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		ux.Logger.PrintToUser("No unused binaries found")
+		return nil
+	}
+	ux.Logger.PrintToUser("Total reclaimed: %s", utils.FormatBytes(total))
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forceRemove bool
+
+	errBinaryInUse = errors.New("binary version is in use")
+)
+
+// avalanche binaries remove
+func newRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [binary] [version]",
+		Short: "Remove an installed version of avalanchego or of a VM",
+		Long: `The binaries remove command removes the given installed version of avalanchego,
+subnet-evm or spacesvm.
+
+Versions referenced by a subnet or by a running process are only removed with --force.
+Subnets referencing a removed version download it again when needed.`,
+		RunE:         removeBinary,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+	}
+	cmd.Flags().BoolVar(&forceRemove, "force", false, "remove the version even if it's referenced")
+	return cmd
+}
+
+func removeBinary(_ *cobra.Command, args []string) error {
+	binary, err := binutils.GetInstalledBinary(app, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	references, err := binutils.GetBinaryReferences(app)
+	if err != nil {
+		return err
+	}
+	if len(references[binary.Path]) > 0 && !forceRemove {
+		return fmt.Errorf("%w by %s. Use --force to remove it anyway",
+			errBinaryInUse, strings.Join(references[binary.Path], ", "))
+	}
+	if err := os.RemoveAll(binary.Path); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Removed %s %s, reclaimed %s", binary.Name, binary.Version, utils.FormatBytes(binary.Size))
+	return nil
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binariescmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/spf13/cobra"
+)
+
+// avalanche binaries which
+func newWhichCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "which [binary] [version]",
+		Short: "Print the path of an installed version of avalanchego or of a VM",
+		Long: `The binaries which command prints the path of the executable of the given installed
+version of avalanchego, subnet-evm or spacesvm.`,
+		RunE:         whichBinary,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+	}
+}
+
+func whichBinary(_ *cobra.Command, args []string) error {
+	binary, err := binutils.GetInstalledBinary(app, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	execPath, err := binutils.GetBinaryExecPath(app, binary)
+	if err != nil {
+		return err
+	}
+	// printed bare, to be usable from scripts
+	fmt.Println(execPath)
+	return nil
+}
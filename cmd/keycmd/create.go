@@ -28,6 +28,12 @@ func createKey(_ *cobra.Command, args []string) error {
 		return errors.New("key name contains whitespace")
 	}
 
+	lock, err := app.LockKey(keyName)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	if app.KeyExists(keyName) && !forceCreate {
 		return errors.New("key already exists. Use --" + forceFlag + " parameter to overwrite")
 	}
@@ -45,6 +45,12 @@ func deleteKey(_ *cobra.Command, args []string) error {
 		return errors.New("key does not exist")
 	}
 
+	lock, err := app.LockKey(keyName)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
 	if !forceDelete {
 		confStr := "Are you sure you want to delete " + keyName + "?"
 		conf, err := app.Prompt.CaptureNoYes(confStr)
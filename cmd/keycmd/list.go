@@ -115,7 +115,7 @@ func getClients(networks []models.Network, cchain bool) (
 	apiEndpoints := map[models.Network]string{
 		models.Fuji:    constants.FujiAPIEndpoint,
 		models.Mainnet: constants.MainnetAPIEndpoint,
-		models.Local:   constants.LocalAPIEndpoint,
+		models.Local:   app.GetLocalAPIEndpoint(),
 	}
 	var err error
 	pClients := map[models.Network]platformvm.Client{}
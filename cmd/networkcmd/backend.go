@@ -0,0 +1,151 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network backend
+func newBackendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backend",
+		Short: "Manage the backend controller of the local network",
+		Long: `The network backend command suite inspects and restarts the backend controller
+process that runs the local network on behalf of the CLI.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	// network backend status
+	cmd.AddCommand(newBackendStatusCmd())
+	// network backend restart
+	cmd.AddCommand(newBackendRestartCmd())
+	return cmd
+}
+
+// avalanche network backend status
+func newBackendStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Print the status of the backend controller",
+		Long: `The network backend status command prints whether the backend controller of the local
+network is running and answering, and the CLI version that started it.
+
+A run file left by a backend that is no longer running is removed.`,
+		RunE:         backendStatus,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+// avalanche network backend restart
+func newBackendRestartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart",
+		Short: "Restart the backend controller, preserving the network",
+		Long: `The network backend restart command restarts the backend controller of the local network
+with this version of the CLI.
+
+If the backend is answering, its network is saved to a temporary snapshot and loaded back
+by the new backend. If the backend crashed leaving the network nodes running, the nodes are
+stopped and the network is restored from its last snapshot.`,
+		RunE:         restartBackend,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func backendStatus(*cobra.Command, []string) error {
+	ln, err := app.LoadLocalNetwork()
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Local network: %s", app.GetLocalNetworkName())
+	ux.Logger.PrintToUser("gRPC endpoint: %s", ln.GRPCServerEndpoint())
+
+	status, err := binutils.GetBackendStatus(app)
+	if err != nil {
+		if !errors.Is(err, binutils.ErrBackendNotRunning) {
+			return err
+		}
+		ux.Logger.PrintToUser("Backend controller is not running (%s)", err)
+		if _, statErr := os.Stat(app.GetRunFile()); statErr == nil {
+			if err := os.Remove(app.GetRunFile()); err != nil {
+				return err
+			}
+			ux.Logger.PrintToUser("Removed stale run file %s", app.GetRunFile())
+		}
+		return printOrphanNodes()
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"PID", "Started at", "Started by CLI version", "Responding", "Output"})
+	responding := logging.Green.Wrap("yes")
+	if !status.Responding {
+		responding = logging.Red.Wrap("no")
+	}
+	startedAt := constants.NotAvailableLabel
+	if !status.StartedAt.IsZero() {
+		startedAt = status.StartedAt.Format(constants.TimeParseLayout)
+	}
+	version := status.Version
+	if version == "" {
+		version = constants.NotAvailableLabel
+	}
+	table.Append([]string{strconv.Itoa(status.Pid), startedAt, version, responding, status.OutputPath})
+	table.Render()
+
+	if !status.Responding {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(
+			"The backend controller is not answering, restart it with `avalanche network backend restart`"))
+	} else if !status.VersionMatches(app) {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"The backend controller was started by another CLI version than this one (%s), "+
+				"restart it with `avalanche network backend restart`", binutils.GetCLIVersion(app))))
+	}
+	return nil
+}
+
+// reports the nodes left running by a backend that crashed
+func printOrphanNodes() error {
+	orphanNodes, err := binutils.GetNodeProcesses(app)
+	if err != nil {
+		return err
+	}
+	if len(orphanNodes) > 0 {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Found %d nodes left running by a backend controller that stopped unexpectedly. "+
+				"Restore the network with `avalanche network backend restart`", len(orphanNodes))))
+	}
+	return nil
+}
+
+func restartBackend(*cobra.Command, []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	if err := subnet.RestartBackend(app); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Backend controller restarted")
+	return nil
+}
@@ -3,20 +3,32 @@
 package networkcmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/shirou/gopsutil/process"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
-var hard bool
+var (
+	errHardSelectiveClean = errors.New("--hard can't be combined with --subnet, --older-than or --prune-binaries")
+	errSubnetRunning      = errors.New("subnet is running on the local network, stop or clean the network first")
+
+	hard           bool
+	cleanSubnets   []string
+	cleanOlderThan time.Duration
+	pruneBinaries  bool
+)
 
 func newCleanCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -24,7 +36,18 @@ func newCleanCmd() *cobra.Command {
 		Short: "Stop the running local network and delete state",
 		Long: `The network clean command shuts down your local, multi-node network. All deployed Subnets
 shutdown and delete their state. You can restart the network by deploying a new Subnet
-configuration.`,
+configuration.
+
+The --subnet, --older-than and --prune-binaries flags instead clean only the given
+things, leaving the network running, and report the disk space reclaimed:
+  --subnet removes the local deployment of the given Subnets from their configuration,
+    along with the VM binaries installed for them on the local network, unless a
+    snapshot of the local network still has a chain using them
+  --older-than removes the run dirs of the local network and the rotated CLI logs
+    not written to for the given duration (ex: 168h)
+  --prune-binaries removes the installed avalanchego and VM versions not referenced by
+    any Subnet configuration nor run by a local network node
+Keys and Subnet configurations are never removed.`,
 		RunE:         clean,
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
@@ -36,14 +59,30 @@ configuration.`,
 		false,
 		"Also clean downloaded avalanchego and plugin binaries",
 	)
+	cmd.Flags().StringSliceVar(&cleanSubnets, "subnet", nil, "only remove the local deployment of these subnets")
+	cmd.Flags().DurationVar(&cleanOlderThan, "older-than", 0, "only remove run dirs and logs older than this (ex: 168h)")
+	cmd.Flags().BoolVar(&pruneBinaries, "prune-binaries", false, "only remove avalanchego and VM versions not in use")
 
 	return cmd
 }
 
 func clean(*cobra.Command, []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	if len(cleanSubnets) > 0 || cleanOlderThan > 0 || pruneBinaries {
+		if hard {
+			return errHardSelectiveClean
+		}
+		return selectiveClean()
+	}
+
 	app.Log.Info("killing gRPC server process...")
 
-	if err := subnet.SetDefaultSnapshot(app.GetSnapshotsDir(), true); err != nil {
+	if err := subnet.SetDefaultSnapshot(app, true); err != nil {
 		app.Log.Warn("failed resetting default snapshot", zap.Error(err))
 	}
 
@@ -75,6 +114,86 @@ func clean(*cobra.Command, []string) error {
 	return nil
 }
 
+// cleans only what the flags select, reporting the space reclaimed by each action
+func selectiveClean() error {
+	var total int64
+
+	if len(cleanSubnets) > 0 {
+		clusterInfo, err := getRunningClusterInfo()
+		if err != nil {
+			return err
+		}
+		for _, subnetName := range cleanSubnets {
+			for _, chainInfo := range clusterInfo.GetCustomChains() {
+				if chainInfo.GetChainName() == subnetName {
+					return fmt.Errorf("%w: %s", errSubnetRunning, subnetName)
+				}
+			}
+		}
+		for _, subnetName := range cleanSubnets {
+			reclaimed, err := subnet.RemoveLocalDeployment(app, subnetName)
+			if err != nil {
+				return err
+			}
+			ux.Logger.PrintToUser("Removed local deployment of subnet %s, reclaimed %s", subnetName, utils.FormatBytes(reclaimed))
+			total += reclaimed
+		}
+	}
+
+	if cleanOlderThan > 0 {
+		inUse, err := getRunPathsInUse()
+		if err != nil {
+			return err
+		}
+		cutoff := time.Now().Add(-cleanOlderThan)
+		removed, reclaimed, err := subnet.RemoveOldRuns(app, cutoff, inUse)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Removed %d run dirs older than %s, reclaimed %s", len(removed), cleanOlderThan, utils.FormatBytes(reclaimed))
+		total += reclaimed
+		removed, reclaimed, err = subnet.RemoveOldLogs(app, cutoff)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Removed %d log files older than %s, reclaimed %s", len(removed), cleanOlderThan, utils.FormatBytes(reclaimed))
+		total += reclaimed
+	}
+
+	if pruneBinaries {
+		removed, err := binutils.PruneBinaries(app)
+		for _, binary := range removed {
+			ux.Logger.PrintToUser("Removed %s %s, reclaimed %s", binary.Name, binary.Version, utils.FormatBytes(binary.Size))
+			total += binary.Size
+		}
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			ux.Logger.PrintToUser("No unused binaries found")
+		}
+	}
+
+	ux.Logger.PrintToUser("Total reclaimed: %s", utils.FormatBytes(total))
+	return nil
+}
+
+// returns the backend output and the node log dirs of the running local network
+func getRunPathsInUse() ([]string, error) {
+	inUse := []string{}
+	if serverOutputPath, err := binutils.GetServerOutputPath(app); err == nil {
+		inUse = append(inUse, serverOutputPath)
+	}
+	clusterInfo, err := getRunningClusterInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeInfo := range clusterInfo.GetNodeInfos() {
+		inUse = append(inUse, nodeInfo.GetLogDir())
+	}
+	return inUse, nil
+}
+
 func cleanBins(dir string) {
 	if err := os.RemoveAll(dir); err != nil {
 		ux.Logger.PrintToUser("Removal failed: %s", err)
@@ -0,0 +1,229 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/localnetworkinterface"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	logFileSuffix        = ".log"
+	logsFollowInterval   = 500 * time.Millisecond
+	cliLogSourceName     = "cli"
+	backendLogSourceName = "backend"
+)
+
+var (
+	errNoLogSources = errors.New("no logs found")
+
+	logNodes     []string
+	logSubnets   []string
+	logLevel     string
+	logTailLines int
+	followLogs   bool
+)
+
+// avalanche network logs
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print the logs of the local network",
+		Long: `The network logs command prints the logs of the nodes of the running local network,
+of the backend controller and of this tool, interleaved by timestamp.
+
+The --node and --subnet flags only print the node logs of the given nodes and of the chains
+of the given locally deployed subnets. The --level flag skips the lines below the given
+level, and --follow keeps printing lines as they're logged.`,
+		RunE:         printLogs,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringSliceVar(&logNodes, "node", nil, "only print the logs of these nodes")
+	cmd.Flags().StringSliceVar(&logSubnets, "subnet", nil, "only print the node logs of the chains of these subnets")
+	cmd.Flags().StringVar(&logLevel, "level", "info", "minimum level of the lines to print (verbo, debug, trace, info, warn, error, fatal)")
+	cmd.Flags().IntVarP(&logTailLines, "tail", "n", 100, "number of past lines to print, 0 for all")
+	cmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "keep printing new lines until interrupted")
+	return cmd
+}
+
+func printLogs(*cobra.Command, []string) error {
+	minLevel, err := logging.ToLevel(logLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level %q: %w", logLevel, err)
+	}
+
+	sources, err := getLogSources()
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return errNoLogSources
+	}
+
+	reader := localnetworkinterface.NewLogReader(sources)
+	lines, err := reader.Read(minLevel)
+	if err != nil {
+		return err
+	}
+	printLogLines(localnetworkinterface.TailLogLines(lines, logTailLines))
+	if !followLogs {
+		return nil
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	ticker := time.NewTicker(logsFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sigc:
+			return nil
+		case <-ticker.C:
+			lines, err := reader.Read(minLevel)
+			if err != nil {
+				return err
+			}
+			printLogLines(lines)
+		}
+	}
+}
+
+// lines are printed directly, as printing them to the user also
+// logs them, which would feed the CLI log back when following it
+func printLogLines(lines []localnetworkinterface.LogLine) {
+	for _, line := range lines {
+		fmt.Printf("%s %s\n", logging.Cyan.Wrap(fmt.Sprintf("%-16s", line.Source)), line.Text)
+	}
+}
+
+// returns the node logs selected by flags, and the logs of the backend and of the
+// CLI if no node or subnet was selected
+func getLogSources() ([]localnetworkinterface.LogSource, error) {
+	sources := []localnetworkinterface.LogSource{}
+	nodeSources, err := getNodeLogSources()
+	if err != nil {
+		return nil, err
+	}
+	sources = append(sources, nodeSources...)
+
+	if len(logNodes) > 0 || len(logSubnets) > 0 {
+		return sources, nil
+	}
+	if serverOutputPath, err := binutils.GetServerOutputPath(app); err == nil {
+		sources = append(sources, localnetworkinterface.LogSource{Name: backendLogSourceName, Path: serverOutputPath})
+	} else {
+		app.Log.Debug("backend output not found", zap.Error(err))
+	}
+	sources = append(sources, localnetworkinterface.LogSource{
+		Name: cliLogSourceName,
+		Path: filepath.Join(app.GetBaseDir(), constants.LogDir, constants.CLILogName+logFileSuffix),
+	})
+	return sources, nil
+}
+
+// returns the log files of the nodes of the running network, named <node>/<chain>
+func getNodeLogSources() ([]localnetworkinterface.LogSource, error) {
+	clusterInfo, err := getRunningClusterInfo()
+	if err != nil {
+		return nil, err
+	}
+	if clusterInfo == nil {
+		if len(logNodes) > 0 || len(logSubnets) > 0 {
+			return nil, errors.New("no local network running, node logs are not available")
+		}
+		ux.Logger.PrintToUser("No local network running, printing the backend and CLI logs only")
+		return nil, nil
+	}
+
+	nodeNames := logNodes
+	if len(nodeNames) == 0 {
+		nodeNames = clusterInfo.NodeNames
+	}
+	sort.Strings(nodeNames)
+
+	// only the log files of these blockchains, if any
+	selectedChains := map[string]struct{}{}
+	for _, subnetName := range logSubnets {
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load sidecar: %w", err)
+		}
+		blockchainID := sc.Networks[app.GetNetworkKey(models.Local)].BlockchainID
+		if blockchainID == ids.Empty {
+			return nil, fmt.Errorf("subnet %s has not been deployed to the local network", subnetName)
+		}
+		selectedChains[blockchainID.String()] = struct{}{}
+	}
+	subnetNames := subnet.GetLocalSubnetNames(app)
+
+	sources := []localnetworkinterface.LogSource{}
+	for _, nodeName := range nodeNames {
+		nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+		if !ok {
+			return nil, fmt.Errorf("unknown node %q, the network has nodes %v", nodeName, clusterInfo.NodeNames)
+		}
+		entries, err := os.ReadDir(nodeInfo.GetLogDir())
+		if err != nil {
+			return nil, fmt.Errorf("failed reading logs of node %s: %w", nodeName, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), logFileSuffix) {
+				continue
+			}
+			chain := strings.TrimSuffix(entry.Name(), logFileSuffix)
+			if len(selectedChains) > 0 {
+				if _, ok := selectedChains[chain]; !ok {
+					continue
+				}
+			}
+			if subnetName, ok := subnetNames[chain]; ok {
+				chain = subnetName
+			}
+			sources = append(sources, localnetworkinterface.LogSource{
+				Name: nodeName + "/" + chain,
+				Path: filepath.Join(nodeInfo.GetLogDir(), entry.Name()),
+			})
+		}
+	}
+	return sources, nil
+}
+
+// returns the cluster info of the running local network, or nil if it's not running
+func getRunningClusterInfo() (*rpcpb.ClusterInfo, error) {
+	isRunning, err := binutils.NewProcessChecker().IsServerProcessRunning(app)
+	if err != nil || !isRunning {
+		return nil, err
+	}
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	status, err := cli.Status(binutils.GetAsyncContext())
+	if err != nil {
+		app.Log.Debug("failed getting network status", zap.Error(err))
+		return nil, nil
+	}
+	return status.GetClusterInfo(), nil
+}
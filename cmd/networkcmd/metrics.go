@@ -0,0 +1,66 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/metrics"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network metrics
+func newMetricsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Generate Prometheus and Grafana configs for the local network",
+		Long: `The network metrics command writes a Prometheus config scraping the metrics
+endpoint of every node of the running local network, and a Grafana dashboard with
+panels for the nodes and for each deployed Subnet.
+
+Once generated, the node targets are rewritten each time nodes are added, removed or
+restarted, or the network is restarted, so a running Prometheus picks them up
+without being restarted.`,
+		RunE:         generateMetricsConfig,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+func generateMetricsConfig(*cobra.Command, []string) error {
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+
+	status, err := cli.Status(binutils.GetAsyncContext())
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			ux.Logger.PrintToUser("No local network running. Please start the network first.")
+		}
+		return err
+	}
+	if err := subnet.WriteMetricsConfig(app, status.GetClusterInfo()); err != nil {
+		return fmt.Errorf("failed writing metrics config: %w", err)
+	}
+
+	metricsDir := app.GetMetricsDir()
+	configPath := filepath.Join(metricsDir, metrics.PrometheusConfigFileName)
+	dashboardPath := filepath.Join(metricsDir, metrics.GrafanaDashboardFileName)
+	ux.Logger.PrintToUser("Prometheus config written to %s", configPath)
+	ux.Logger.PrintToUser("Prometheus targets written to %s", filepath.Join(metricsDir, metrics.PrometheusTargetsFileName))
+	ux.Logger.PrintToUser("Grafana dashboard written to %s", dashboardPath)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Start Prometheus with:")
+	ux.Logger.PrintToUser("  prometheus --config.file=%s", configPath)
+	ux.Logger.PrintToUser("Then add it as a Prometheus data source in Grafana and import %s", dashboardPath)
+	ux.Logger.PrintToUser("The targets are updated automatically as the nodes of the network change")
+	return nil
+}
@@ -5,6 +5,8 @@ package networkcmd
 import (
 	"fmt"
 
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd/nodecmd"
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd/snapshotcmd"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/spf13/cobra"
 )
@@ -23,7 +25,8 @@ When you deploy a Subnet locally, it runs on a local, multi-node Avalanche netwo
 subnet deploy command starts this network in the background. This command suite allows you
 to shutdown, restart, and clear that network.
 
-This network currently supports multiple, concurrently deployed Subnets.`,
+This network currently supports multiple, concurrently deployed Subnets. Several named local
+networks can also run side by side, see network start --name and network use.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			err := cmd.Help()
 			if err != nil {
@@ -40,5 +43,19 @@ This network currently supports multiple, concurrently deployed Subnets.`,
 	cmd.AddCommand(newCleanCmd())
 	// network status
 	cmd.AddCommand(newStatusCmd())
+	// network logs
+	cmd.AddCommand(newLogsCmd())
+	// network metrics
+	cmd.AddCommand(newMetricsCmd())
+	// network upgrade
+	cmd.AddCommand(newUpgradeCmd())
+	// network use
+	cmd.AddCommand(newUseCmd())
+	// network backend
+	cmd.AddCommand(newBackendCmd())
+	// network snapshot
+	cmd.AddCommand(snapshotcmd.NewCmd(app))
+	// network node
+	cmd.AddCommand(nodecmd.NewCmd(app))
 	return cmd
 }
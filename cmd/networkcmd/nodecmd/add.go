@@ -0,0 +1,209 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/key"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// avalanchego config key of the subnets a node tracks
+	whitelistedSubnetsKey = "whitelisted-subnets"
+	// minimum stake of a primary network validator on the local network
+	localValidatorStake = 2000 * units.Avax
+)
+
+var (
+	errNodeExists    = errors.New("node already exists")
+	errNotDeployed   = errors.New("subnet has not been deployed to the local network")
+	errInvalidConfig = errors.New("invalid node config")
+
+	addAvagoVersion string
+	addAvagoPath    string
+	addConfigPath   string
+	validateSubnet  string
+	validatorWeight uint64
+)
+
+// avalanche network node add
+func newAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add [nodeName]",
+		Short: "Add a node to the running local network",
+		Long: `The network node add command adds a new node to the running local network. The node
+tracks all subnets of the network.
+
+By default, the node uses the avalanchego binary of the other nodes and the global node
+config. The --avalanchego-version and --avalanchego-path flags choose another binary, and
+--config overrides config values with the ones in the given JSON file.
+
+With --validate-subnet, the node also becomes a validator of the primary network and of the
+given locally deployed subnet, funded by the local network's prefunded key.`,
+		RunE:         addNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&addAvagoVersion, "avalanchego-version", "", "use this version of avalanchego (ex: v1.9.4), or latest")
+	cmd.Flags().StringVar(&addAvagoPath, "avalanchego-path", "", "use the avalanchego binary at this path")
+	cmd.Flags().StringVar(&addConfigPath, "config", "", "JSON file with avalanchego config values for the node")
+	cmd.Flags().StringVar(&validateSubnet, "validate-subnet", "", "make the node a validator of this locally deployed subnet")
+	cmd.Flags().Uint64Var(&validatorWeight, "weight", constants.DefaultStakeWeight, "weight of the node as a subnet validator")
+	return cmd
+}
+
+func addNode(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	nodeName := args[0]
+
+	var subnetID ids.ID
+	if validateSubnet != "" {
+		sc, err := app.LoadSidecar(validateSubnet)
+		if err != nil {
+			return fmt.Errorf("failed to load sidecar: %w", err)
+		}
+		subnetID = sc.Networks[app.GetNetworkKey(models.Local)].SubnetID
+		if subnetID == ids.Empty {
+			return fmt.Errorf("%w: %s", errNotDeployed, validateSubnet)
+		}
+	}
+
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	clusterInfo, err := getClusterInfo(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if _, ok := clusterInfo.NodeInfos[nodeName]; ok {
+		return fmt.Errorf("%w: %s", errNodeExists, nodeName)
+	}
+
+	avalancheGoBinPath, err := getAvalancheGoBinPath(addAvagoVersion, addAvagoPath)
+	if err != nil {
+		return err
+	}
+	if avalancheGoBinPath == "" && len(clusterInfo.NodeNames) > 0 {
+		avalancheGoBinPath = clusterInfo.NodeInfos[clusterInfo.NodeNames[0]].ExecPath
+	}
+
+	nodeConfig, err := getNewNodeConfig(clusterInfo)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Adding node %s...", nodeName)
+	resp, err := cli.AddNode(
+		ctx,
+		nodeName,
+		avalancheGoBinPath,
+		client.WithGlobalNodeConfig(nodeConfig),
+		client.WithPluginDir(app.GetPluginsDir()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed adding node %s: %w", nodeName, err)
+	}
+	clusterInfo, err = subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
+	nodeInfo := resp.ClusterInfo.NodeInfos[nodeName]
+	ux.Logger.PrintToUser("Node %s added, ID: %s, URI: %s", nodeName, nodeInfo.Id, nodeInfo.Uri)
+
+	if validateSubnet == "" {
+		return nil
+	}
+	nodeID, err := ids.NodeIDFromString(nodeInfo.Id)
+	if err != nil {
+		return err
+	}
+	return addLocalValidator(nodeID, subnetID)
+}
+
+// returns the config of a new node: the global node config, overridden by the
+// values in --config, tracking all the subnets of the network
+func getNewNodeConfig(clusterInfo *rpcpb.ClusterInfo) (string, error) {
+	config := map[string]interface{}{}
+	globalConfig, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return "", err
+	}
+	if globalConfig != "" {
+		if err := json.Unmarshal([]byte(globalConfig), &config); err != nil {
+			return "", fmt.Errorf("%w: global node config: %s", errInvalidConfig, err)
+		}
+	}
+	if addConfigPath != "" {
+		configBytes, err := os.ReadFile(addConfigPath)
+		if err != nil {
+			return "", err
+		}
+		overrides := map[string]interface{}{}
+		if err := json.Unmarshal(configBytes, &overrides); err != nil {
+			return "", fmt.Errorf("%w: %s: %s", errInvalidConfig, addConfigPath, err)
+		}
+		for k, v := range overrides {
+			config[k] = v
+		}
+	}
+	trackedSubnets := clusterInfo.Subnets
+	if configured, ok := config[whitelistedSubnetsKey].(string); ok && configured != "" {
+		trackedSubnets = append([]string{configured}, trackedSubnets...)
+	}
+	if len(trackedSubnets) > 0 {
+		config[whitelistedSubnetsKey] = strings.Join(trackedSubnets, ",")
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(configBytes), nil
+}
+
+// makes [nodeID] a validator of the primary network and of [subnetID], paying with
+// the prefunded key of the local network, which also controls the local subnets
+func addLocalValidator(nodeID ids.NodeID, subnetID ids.ID) error {
+	sk, err := key.NewSoft(constants.LocalNetworkID, key.WithPrivateKeyEncoded(key.EwoqPrivateKey))
+	if err != nil {
+		return err
+	}
+	deployer := subnet.NewPublicDeployer(app, false, sk.KeyChain(), models.Local)
+	startTime := time.Now().Add(constants.StakingStartLeadTime)
+
+	ux.Logger.PrintToUser("Adding node %s as a primary network validator...", nodeID)
+	if _, err := deployer.AddPrimaryValidator(nodeID, localValidatorStake, startTime, constants.MaxStakeDuration); err != nil {
+		return fmt.Errorf("failed adding primary network validator: %w", err)
+	}
+	ux.Logger.PrintToUser("Adding node %s as a validator of subnet %s...", nodeID, validateSubnet)
+	if _, _, err := deployer.AddValidator(sk.P(), subnetID, nodeID, validatorWeight, startTime, constants.MaxStakeDuration); err != nil {
+		return fmt.Errorf("failed adding subnet validator: %w", err)
+	}
+	ux.Logger.PrintToUser("Node %s will start validating subnet %s at %s", nodeID, validateSubnet, startTime.Format(constants.TimeParseLayout))
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	app *application.Avalanche
+
+	errUnknownNode = errors.New("unknown node")
+)
+
+// avalanche network node
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Manage the nodes of the running local network",
+		Long: `The network node command suite provides a collection of tools for adding, removing,
+pausing and restarting the nodes of the running local network, for example to test
+validator churn and the fault tolerance of a VM.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	app = injectedApp
+	// network node add
+	cmd.AddCommand(newAddCmd())
+	// network node remove
+	cmd.AddCommand(newRemoveCmd())
+	// network node pause
+	cmd.AddCommand(newPauseCmd())
+	// network node resume
+	cmd.AddCommand(newResumeCmd())
+	// network node restart
+	cmd.AddCommand(newRestartCmd())
+	return cmd
+}
+
+// returns the cluster info of the running local network
+func getClusterInfo(ctx context.Context, cli client.Client) (*rpcpb.ClusterInfo, error) {
+	status, err := cli.Status(ctx)
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			ux.Logger.PrintToUser("No local network running. Please start the network first.")
+		}
+		return nil, err
+	}
+	return status.ClusterInfo, nil
+}
+
+// returns the info of node [nodeName] of the running local network
+func getNodeInfo(ctx context.Context, cli client.Client, nodeName string) (*rpcpb.NodeInfo, error) {
+	clusterInfo, err := getClusterInfo(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	nodeInfo, ok := clusterInfo.NodeInfos[nodeName]
+	if !ok {
+		return nil, fmt.Errorf("%w %q, the network has nodes %v", errUnknownNode, nodeName, clusterInfo.NodeNames)
+	}
+	return nodeInfo, nil
+}
+
+// returns the path of the avalanchego binary given by flags, or "" if none was given
+func getAvalancheGoBinPath(avagoVersion string, avagoPath string) (string, error) {
+	if avagoPath != "" {
+		if _, err := os.Stat(avagoPath); err != nil {
+			return "", fmt.Errorf("avalanchego binary %s not found: %w", avagoPath, err)
+		}
+		return avagoPath, nil
+	}
+	if avagoVersion == "" {
+		return "", nil
+	}
+	if avagoVersion == "latest" {
+		var err error
+		avagoVersion, err = app.Downloader.GetLatestReleaseVersion(binutils.GetGithubLatestReleaseURL(
+			constants.AvaLabsOrg,
+			constants.AvalancheGoRepoName,
+		))
+		if err != nil {
+			return "", err
+		}
+	}
+	avagoDir, err := binutils.SetupAvalanchego(app, avagoVersion)
+	if err != nil {
+		return "", fmt.Errorf("failed installing avalanchego %s: %w", avagoVersion, err)
+	}
+	return filepath.Join(avagoDir, "avalanchego"), nil
+}
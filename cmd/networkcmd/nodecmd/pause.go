@@ -0,0 +1,95 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/shirou/gopsutil/process"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node pause
+func newPauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause [nodeName]",
+		Short: "Pause a node of the running local network",
+		Long: `The network node pause command suspends the process of the given node, which then
+stops responding to its peers and to API calls while keeping its state. Resume it with
+network node resume.
+
+The network reports itself unhealthy while a node is paused.`,
+		RunE:         pauseNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+}
+
+// avalanche network node resume
+func newResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "resume [nodeName]",
+		Short:        "Resume a paused node of the running local network",
+		Long:         `The network node resume command resumes a node paused with network node pause.`,
+		RunE:         resumeNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+}
+
+func pauseNode(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	nodeName := args[0]
+	proc, err := getNodeProcess(nodeName)
+	if err != nil {
+		return err
+	}
+	if err := proc.Suspend(); err != nil {
+		return fmt.Errorf("failed pausing node %s: %w", nodeName, err)
+	}
+	ux.Logger.PrintToUser("Node %s paused", nodeName)
+	return nil
+}
+
+func resumeNode(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	nodeName := args[0]
+	proc, err := getNodeProcess(nodeName)
+	if err != nil {
+		return err
+	}
+	if err := proc.Resume(); err != nil {
+		return fmt.Errorf("failed resuming node %s: %w", nodeName, err)
+	}
+	ux.Logger.PrintToUser("Node %s resumed", nodeName)
+	return nil
+}
+
+// the network runner has no pause support, so nodes are paused
+// by suspending their process
+func getNodeProcess(nodeName string) (*process.Process, error) {
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	nodeInfo, err := getNodeInfo(ctx, cli, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	return binutils.GetNodeProcess(nodeInfo.LogDir)
+}
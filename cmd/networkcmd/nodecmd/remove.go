@@ -0,0 +1,54 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node remove
+func newRemoveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [nodeName]",
+		Short: "Remove a node from the running local network",
+		Long: `The network node remove command stops the given node and removes it from the running
+local network. If the node is a validator, it stays in the validator set until its
+validation period ends.`,
+		RunE:         removeNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+}
+
+func removeNode(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	nodeName := args[0]
+
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	if _, err := getNodeInfo(ctx, cli, nodeName); err != nil {
+		return err
+	}
+	resp, err := cli.RemoveNode(ctx, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed removing node %s: %w", nodeName, err)
+	}
+	subnet.UpdateMetricsConfig(app, resp.ClusterInfo)
+	ux.Logger.PrintToUser("Node %s removed", nodeName)
+	return nil
+}
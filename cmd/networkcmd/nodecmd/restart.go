@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	restartAvagoVersion string
+	restartAvagoPath    string
+)
+
+// avalanche network node restart
+func newRestartCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restart [nodeName]",
+		Short: "Restart a node of the running local network",
+		Long: `The network node restart command restarts the given node, keeping its state. The node
+tracks all subnets of the network after the restart.
+
+The --avalanchego-version and --avalanchego-path flags restart the node with another
+avalanchego binary.`,
+		RunE:         restartNode,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&restartAvagoVersion, "avalanchego-version", "", "restart with this version of avalanchego (ex: v1.9.4), or latest")
+	cmd.Flags().StringVar(&restartAvagoPath, "avalanchego-path", "", "restart with the avalanchego binary at this path")
+	return cmd
+}
+
+func restartNode(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	nodeName := args[0]
+
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	clusterInfo, err := getClusterInfo(ctx, cli)
+	if err != nil {
+		return err
+	}
+	if _, ok := clusterInfo.NodeInfos[nodeName]; !ok {
+		return fmt.Errorf("%w %q, the network has nodes %v", errUnknownNode, nodeName, clusterInfo.NodeNames)
+	}
+
+	opts := []client.OpOption{
+		client.WithPluginDir(app.GetPluginsDir()),
+	}
+	avalancheGoBinPath, err := getAvalancheGoBinPath(restartAvagoVersion, restartAvagoPath)
+	if err != nil {
+		return err
+	}
+	if avalancheGoBinPath != "" {
+		opts = append(opts, client.WithExecPath(avalancheGoBinPath))
+	}
+	if len(clusterInfo.Subnets) > 0 {
+		opts = append(opts, client.WithWhitelistedSubnets(strings.Join(clusterInfo.Subnets, ",")))
+	}
+
+	ux.Logger.PrintToUser("Restarting node %s...", nodeName)
+	if _, err := cli.RestartNode(ctx, nodeName, opts...); err != nil {
+		return fmt.Errorf("failed restarting node %s: %w", nodeName, err)
+	}
+	clusterInfo, err = subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
+	ux.Logger.PrintToUser("Node %s restarted", nodeName)
+	return nil
+}
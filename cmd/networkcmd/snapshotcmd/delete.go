@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var errDefaultSnapshot = errors.New("the default snapshot can't be deleted, use network clean to reset it")
+
+// avalanche network snapshot delete
+func newDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "delete [snapshotName]",
+		Short:        "Delete a snapshot of the local network",
+		Long:         `The network snapshot delete command deletes the given snapshot of the local network.`,
+		RunE:         deleteSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+}
+
+func deleteSnapshot(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	name := args[0]
+	if name == constants.DefaultSnapshotName {
+		return errDefaultSnapshot
+	}
+	snapshot, err := subnet.GetSnapshot(app, name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(snapshot.Path); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s deleted, %s reclaimed", name, utils.FormatBytes(snapshot.Size))
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+// avalanche network snapshot export
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [snapshotName]",
+		Short: "Export a snapshot of the local network to a tar.gz archive",
+		Long: `The network snapshot export command writes the given snapshot to a tar.gz archive,
+so it can be shared and loaded on another machine with network snapshot import.
+
+The archive includes the VM binaries of the subnets in the snapshot, if the snapshot
+records them.`,
+		RunE:         exportSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write the archive to this file (default <snapshotName>.tar.gz)")
+	return cmd
+}
+
+func exportSnapshot(_ *cobra.Command, args []string) error {
+	name := args[0]
+	if exportOutput == "" {
+		exportOutput = name + ".tar.gz"
+	}
+	if err := subnet.ExportSnapshot(app, name, exportOutput); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s exported to %s", name, exportOutput)
+	return nil
+}
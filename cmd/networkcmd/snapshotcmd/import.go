@@ -0,0 +1,44 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var importName string
+
+// avalanche network snapshot import
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [archivePath]",
+		Short: "Import a snapshot from a tar.gz archive",
+		Long: `The network snapshot import command adds the snapshot in the given archive, written by
+network snapshot export, to the snapshots of the local network. The VM binaries in the
+archive are installed if they're missing.
+
+Start the network from it with network start --snapshot-name <snapshotName>.`,
+		RunE:         importSnapshot,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&importName, "snapshot-name", "", "import the snapshot under this name instead of its original one")
+	return cmd
+}
+
+func importSnapshot(_ *cobra.Command, args []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	name, err := subnet.ImportSnapshot(app, args[0], importName)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s imported. Start the network from it with `avalanche network start --snapshot-name %s`", name, name)
+	return nil
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network snapshot list
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the local network",
+		Long: `The network snapshot list command lists the snapshots of the local network, with
+their size, the date they were saved and the subnets they contain.
+
+Only snapshots saved by this tool record their subnets. Temporary snapshots, left behind by
+network restarts, can be removed with network snapshot prune-temporaries.`,
+		RunE:         listSnapshots,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func listSnapshots(*cobra.Command, []string) error {
+	snapshots, err := subnet.GetSnapshots(app)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		ux.Logger.PrintToUser("No snapshots found")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Snapshot", "Size", "Date", "Temporary", "Subnets"})
+	table.SetAutoMergeCells(true)
+	table.SetRowLine(true)
+	for _, snapshot := range snapshots {
+		temporary := ""
+		if snapshot.IsTemporary() {
+			temporary = "yes"
+		}
+		subnets := "-"
+		if snapshot.Metadata != nil {
+			names := []string{}
+			for _, chain := range snapshot.Metadata.Chains {
+				names = append(names, chain.ChainName)
+			}
+			subnets = strings.Join(names, "\n")
+		}
+		table.Append([]string{
+			snapshot.Name,
+			utils.FormatBytes(snapshot.Size),
+			snapshot.ModTime.Format("2006-01-02 15:04:05"),
+			temporary,
+			subnets,
+		})
+	}
+	table.Render()
+	return nil
+}
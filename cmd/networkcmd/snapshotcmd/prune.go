@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network snapshot prune-temporaries
+func newPruneTemporariesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune-temporaries",
+		Short: "Delete the temporary snapshots of the local network",
+		Long: `The network snapshot prune-temporaries command deletes the temporary snapshots
+saved while restarting the local network, for example during subnet upgrade apply,
+subnet upgrade vm or network upgrade. They're kept to recover the network if a restart
+fails.`,
+		RunE:         pruneTemporaries,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+}
+
+func pruneTemporaries(*cobra.Command, []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	snapshots, err := subnet.GetSnapshots(app)
+	if err != nil {
+		return err
+	}
+	var (
+		pruned    int
+		reclaimed int64
+	)
+	for _, snapshot := range snapshots {
+		if !snapshot.IsTemporary() {
+			continue
+		}
+		if err := os.RemoveAll(snapshot.Path); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Deleted snapshot %s", snapshot.Name)
+		pruned++
+		reclaimed += snapshot.Size
+	}
+	if pruned == 0 {
+		ux.Logger.PrintToUser("No temporary snapshots found")
+		return nil
+	}
+	ux.Logger.PrintToUser("Deleted %d temporary snapshots, %s reclaimed", pruned, utils.FormatBytes(reclaimed))
+	return nil
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/spf13/cobra"
+)
+
+var app *application.Avalanche
+
+// avalanche network snapshot
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage the snapshots of the local network",
+		Long: `The network snapshot command suite provides a collection of tools for managing
+the snapshots of the local network, saved with network stop --snapshot-name or while the
+network restarts during upgrades.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := cmd.Help()
+			if err != nil {
+				fmt.Println(err)
+			}
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	app = injectedApp
+	// network snapshot list
+	cmd.AddCommand(newListCmd())
+	// network snapshot delete
+	cmd.AddCommand(newDeleteCmd())
+	// network snapshot prune-temporaries
+	cmd.AddCommand(newPruneTemporariesCmd())
+	// network snapshot export
+	cmd.AddCommand(newExportCmd())
+	// network snapshot import
+	cmd.AddCommand(newImportCmd())
+	return cmd
+}
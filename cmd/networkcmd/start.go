@@ -3,22 +3,36 @@
 package networkcmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
 	"github.com/ava-labs/avalanche-network-runner/client"
 	"github.com/ava-labs/avalanche-network-runner/server"
 	"github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/spf13/cobra"
 )
 
 var (
-	avagoVersion string
-	snapshotName string
+	avagoVersion     string
+	avagoPath        string
+	snapshotName     string
+	localNetworkName string
+	numNodes         uint32
+	nodeFlags        []string
+	nodeConfigs      []string
+	stakingKeysDir   string
+
+	errMutuallyExclusiveAvago = errors.New("--avalanchego-version and --avalanchego-path are mutually exclusive")
 )
 
 func newStartCmd() *cobra.Command {
@@ -29,7 +43,29 @@ func newStartCmd() *cobra.Command {
 
 By default, the command loads the default snapshot. If you provide the --snapshot-name
 flag, the network loads that snapshot instead. The command fails if the local network is
-already running.`,
+already running.
+
+Several local networks can run side by side. The --name flag starts the local network with
+the given name, creating it if needed, and selects it as the target of local commands.
+Each one has its own run dir, snapshots, plugins and backend ports, chosen among the
+ports free when it is created. Node ports are the ones saved in the snapshot, reassigned
+to free ports when another network already uses them. network use lists the API endpoint
+each network ended up with.
+
+The --num-nodes, --node-flag, --node-config and --staking-keys-dir flags start a new network
+with a fresh genesis instead of loading a snapshot. Subnets deployed to it are created on
+demand, as it has no preloaded subnets. Stopping it saves its state to the default snapshot
+as usual, including the config of each node.
+
+The config of each node of a new network is the global node-config of the CLI config file,
+overridden by its entry in the node-configs map of the CLI config file, then by its
+--node-config file and finally by its --node-flag values. The node-configs map of the CLI
+config file only takes effect on new networks, so it's not applied when loading a snapshot.
+
+The --avalanchego-path flag starts the network with a locally built avalanchego instead of
+a released version. Its reported version and RPC protocol version are checked against the
+compatibility data. If no avalanchego is given, the network restarts with the locally built
+avalanchego its subnets were deployed with, if any.`,
 
 		RunE:         StartNetwork,
 		Args:         cobra.ExactArgs(0),
@@ -37,13 +73,62 @@ already running.`,
 	}
 
 	cmd.Flags().StringVar(&avagoVersion, "avalanchego-version", "latest", "use this version of avalanchego (ex: v1.17.12)")
+	cmd.Flags().StringVar(&avagoPath, "avalanchego-path", "", "use the locally built avalanchego binary at this path")
 	cmd.Flags().StringVar(&snapshotName, "snapshot-name", constants.DefaultSnapshotName, "name of snapshot to use to start the network from")
+	cmd.Flags().StringVar(&localNetworkName, "name", "", "name of the local network to start (defaults to the selected one)")
+	cmd.Flags().Uint32Var(&numNodes, "num-nodes", 0, "start a new network with this number of nodes (default 5 if other topology flags are given)")
+	cmd.Flags().StringSliceVar(&nodeFlags, "node-flag", nil, "avalanchego flag for a node of a new network, as <nodeName>:<flag>=<value> (ex: node1:log-level=debug)")
+	cmd.Flags().StringSliceVar(&nodeConfigs, "node-config", nil, "avalanchego config file for a node of a new network, as <nodeName>=<path> (ex: node1=node1.json). "+
+		"The node-configs of the CLI config file also only apply with --num-nodes, --node-flag, --node-config or --staking-keys-dir")
+	cmd.Flags().StringVar(&stakingKeysDir, "staking-keys-dir", "",
+		"start a new network using the staking keys at <dir>/<nodeName>/staker.{crt,key} for the nodes that have them")
 
 	return cmd
 }
 
-func StartNetwork(*cobra.Command, []string) error {
-	sd := subnet.NewLocalDeployer(app, avagoVersion, "")
+func StartNetwork(cmd *cobra.Command, _ []string) error {
+	if avagoPath != "" && avagoVersion != "latest" {
+		return errMutuallyExclusiveAvago
+	}
+	if err := subnet.ValidateSnapshotName(snapshotName); err != nil {
+		return err
+	}
+	if localNetworkName == "" {
+		localNetworkName = app.GetLocalNetworkName()
+	}
+	if _, err := app.CreateLocalNetwork(localNetworkName); err != nil {
+		return err
+	}
+	if err := app.SelectLocalNetwork(localNetworkName); err != nil {
+		return err
+	}
+	if localNetworkName != constants.DefaultLocalNetworkName {
+		ux.Logger.PrintToUser("Using local network %s", localNetworkName)
+	}
+
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	// restart the subnets deployed with a locally built avalanchego with that binary
+	if avagoPath == "" && !cmd.Flags().Changed("avalanchego-version") {
+		avagoPath, err = getDeployedAvalancheGoPath()
+		if err != nil {
+			return err
+		}
+	}
+
+	localAvagoPath := ""
+	if avagoPath != "" {
+		localAvagoPath, err = getLocalAvalancheGoBinary()
+		if err != nil {
+			return err
+		}
+	}
+
+	sd := subnet.NewLocalDeployer(app, avagoVersion, localAvagoPath, "")
 
 	if err := sd.StartServer(); err != nil {
 		return err
@@ -54,11 +139,51 @@ func StartNetwork(*cobra.Command, []string) error {
 		return err
 	}
 
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(app)
 	if err != nil {
 		return err
 	}
+	defer cli.Close()
+
+	outputDirPrefix := path.Join(app.GetRunDir(), "restart")
+	outputDir, err := utils.MkDirWithTimestamp(outputDirPrefix)
+	if err != nil {
+		return err
+	}
+
+	ctx := binutils.GetAsyncContext()
+
+	if numNodes > 0 || len(nodeFlags) > 0 || len(nodeConfigs) > 0 || stakingKeysDir != "" {
+		err = startFreshNetwork(ctx, sd, cli, avalancheGoBinPath, outputDir)
+	} else {
+		err = startFromSnapshot(ctx, cli, avalancheGoBinPath, outputDir)
+	}
+	if err != nil {
+		if !server.IsServerError(err, server.ErrAlreadyBootstrapped) {
+			return err
+		}
+		ux.Logger.PrintToUser("Network has already been booted. Wait until healthy...")
+	}
+
+	clusterInfo, err := subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	if err := subnet.UpdateLocalAPIEndpoint(app, clusterInfo); err != nil {
+		return err
+	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
+
+	fmt.Println()
+	if subnet.HasEndpoints(clusterInfo) {
+		ux.Logger.PrintToUser("Network ready to use. Local network node endpoints:")
+		ux.PrintTableEndpoints(clusterInfo)
+	}
+
+	return nil
+}
 
+func startFromSnapshot(ctx context.Context, cli client.Client, avalancheGoBinPath string, outputDir string) error {
 	var startMsg string
 	if snapshotName == constants.DefaultSnapshotName {
 		startMsg = "Starting previously deployed and stopped snapshot"
@@ -67,12 +192,6 @@ func StartNetwork(*cobra.Command, []string) error {
 	}
 	ux.Logger.PrintToUser(startMsg)
 
-	outputDirPrefix := path.Join(app.GetRunDir(), "restart")
-	outputDir, err := utils.MkDirWithTimestamp(outputDirPrefix)
-	if err != nil {
-		return err
-	}
-
 	pluginDir := app.GetPluginsDir()
 
 	loadSnapshotOpts := []client.OpOption{
@@ -90,35 +209,137 @@ func StartNetwork(*cobra.Command, []string) error {
 	if configStr != "" {
 		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
 	}
-
-	ctx := binutils.GetAsyncContext()
+	// snapshots keep the config each node was started with
+	perNodeConfigs, err := app.Conf.LoadNodeConfigs()
+	if err != nil {
+		return fmt.Errorf("node-configs of the CLI config file: %w", err)
+	}
+	if len(perNodeConfigs) > 0 {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap("The node-configs of the CLI config file are not applied, as they only " +
+			"take effect on new networks, started with --num-nodes, --node-flag, --node-config or --staking-keys-dir"))
+	}
 
 	pp, err := cli.LoadSnapshot(
 		ctx,
 		snapshotName,
 		loadSnapshotOpts...,
 	)
+	if err != nil {
+		if server.IsServerError(err, server.ErrAlreadyBootstrapped) {
+			return err
+		}
+		return fmt.Errorf("failed to start network with the persisted snapshot: %w", err)
+	}
+	ux.Logger.PrintToUser("Booting Network. Wait until healthy...")
+	ux.Logger.PrintToUser("Node log path: %s/node<i>/logs", pp.ClusterInfo.RootDataDir)
+	return nil
+}
 
+// starts a network with a new genesis and the topology given by flags,
+// instead of loading a snapshot
+func startFreshNetwork(
+	ctx context.Context,
+	sd *subnet.LocalDeployer,
+	cli client.Client,
+	avalancheGoBinPath string,
+	outputDir string,
+) error {
+	if numNodes == 0 {
+		numNodes = constants.LocalNetworkNumNodes
+	}
+	parsedNodeFlags, err := subnet.ParseNodeFlags(nodeFlags)
 	if err != nil {
-		if !server.IsServerError(err, server.ErrAlreadyBootstrapped) {
-			return fmt.Errorf("failed to start network with the persisted snapshot: %w", err)
+		return err
+	}
+	globalConfigStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return err
+	}
+	globalConfig, err := subnet.ParseNodeConfig(globalConfigStr)
+	if err != nil {
+		return fmt.Errorf("node-config of the CLI config file: %w", err)
+	}
+	parsedNodeConfigs, err := loadNodeConfigs()
+	if err != nil {
+		return err
+	}
+	topology := subnet.LocalNetworkTopology{
+		NumNodes:       numNodes,
+		GlobalConfig:   globalConfig,
+		NodeConfigs:    parsedNodeConfigs,
+		NodeFlags:      parsedNodeFlags,
+		StakingKeysDir: stakingKeysDir,
+	}
+	if err := sd.StartFreshNetwork(ctx, cli, avalancheGoBinPath, outputDir, topology); err != nil {
+		if server.IsServerError(err, server.ErrAlreadyBootstrapped) {
+			return err
 		}
-		ux.Logger.PrintToUser("Network has already been booted. Wait until healthy...")
-	} else {
-		ux.Logger.PrintToUser("Booting Network. Wait until healthy...")
-		ux.Logger.PrintToUser("Node log path: %s/node<i>/logs", pp.ClusterInfo.RootDataDir)
+		return fmt.Errorf("failed to start a new network: %w", err)
 	}
+	return nil
+}
 
-	clusterInfo, err := subnet.WaitForHealthy(ctx, cli)
+// returns the configs of specific nodes in the CLI config file,
+// overridden by the ones given with --node-config
+func loadNodeConfigs() (map[string]map[string]interface{}, error) {
+	configStrs, err := app.Conf.LoadNodeConfigs()
 	if err != nil {
-		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+		return nil, err
+	}
+	configs := map[string]map[string]interface{}{}
+	for nodeName, configStr := range configStrs {
+		config, err := subnet.ParseNodeConfig(configStr)
+		if err != nil {
+			return nil, fmt.Errorf("node-configs of the CLI config file: %w", err)
+		}
+		configs[nodeName] = config
+	}
+	fileConfigs, err := subnet.LoadNodeConfigFiles(nodeConfigs)
+	if err != nil {
+		return nil, err
+	}
+	for nodeName, fileConfig := range fileConfigs {
+		if _, ok := configs[nodeName]; !ok {
+			configs[nodeName] = map[string]interface{}{}
+		}
+		for k, v := range fileConfig {
+			configs[nodeName][k] = v
+		}
 	}
+	return configs, nil
+}
 
-	fmt.Println()
-	if subnet.HasEndpoints(clusterInfo) {
-		ux.Logger.PrintToUser("Network ready to use. Local network node endpoints:")
-		ux.PrintTableEndpoints(clusterInfo)
+// returns the locally built avalanchego the subnets deployed to the local network were
+// deployed with, or "" if they use released versions or disagree
+func getDeployedAvalancheGoPath() (string, error) {
+	deployedPaths, err := subnet.GetDeployedAvalancheGoPaths(app)
+	if err != nil {
+		return "", err
+	}
+	if len(deployedPaths) > 1 {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(
+			"The subnets of the local network were deployed with different avalanchego binaries, use --avalanchego-path to choose one"))
+		return "", nil
 	}
+	for deployedPath, subnetNames := range deployedPaths {
+		ux.Logger.PrintToUser("Using the avalanchego binary subnets %s were deployed with, at %s",
+			strings.Join(subnetNames, ", "), deployedPath)
+		return deployedPath, nil
+	}
+	return "", nil
+}
 
-	return nil
+// returns the absolute path of the locally built avalanchego given by --avalanchego-path,
+// checking its version and RPC protocol version agree with the compatibility data
+func getLocalAvalancheGoBinary() (string, error) {
+	localAvagoPath, err := filepath.Abs(avagoPath)
+	if err != nil {
+		return "", err
+	}
+	localAvagoVersion, rpcVersion, err := vm.GetLocalAvalancheGoVersion(app, localAvagoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed checking avalanchego binary %s: %w", localAvagoPath, err)
+	}
+	ux.Logger.PrintToUser("Using avalanchego %s built at %s (RPC protocol version %d)", localAvagoVersion, localAvagoPath, rpcVersion)
+	return localAvagoPath, nil
 }
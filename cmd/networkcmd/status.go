@@ -3,32 +3,63 @@
 package networkcmd
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnetworkinterface"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
 	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
+// moves the cursor to the top left corner and clears the terminal
+const clearScreenSeq = "\033[H\033[2J"
+
+var (
+	watchStatus   bool
+	watchInterval time.Duration
+)
+
 func newStatusCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Prints the status of the local network",
 		Long: `The network status command prints whether or not a local Avalanche
-network is running and some basic stats about the network.`,
+network is running, the status of each of its nodes and of each deployed
+custom chain on every node.
+
+With --watch, the status is printed again each time the network runner pushes
+an update, until interrupted.`,
 
 		RunE:         networkStatus,
 		Args:         cobra.ExactArgs(0),
 		SilenceUsage: true,
 	}
+	cmd.Flags().BoolVar(&watchStatus, "watch", false, "keep printing the status as the network changes")
+	cmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "interval between status updates in watch mode")
+	return cmd
 }
 
 func networkStatus(*cobra.Command, []string) error {
 	ux.Logger.PrintToUser("Requesting network status...")
 
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(app)
 	if err != nil {
 		return err
 	}
+	defer cli.Close()
 
 	ctx := binutils.GetAsyncContext()
 	status, err := cli.Status(ctx)
@@ -39,31 +70,134 @@ func networkStatus(*cobra.Command, []string) error {
 		}
 		return err
 	}
+	if status == nil || status.ClusterInfo == nil {
+		ux.Logger.PrintToUser("No local network running")
+		return nil
+	}
+
+	if !watchStatus {
+		printClusterStatus(status.ClusterInfo)
+		return nil
+	}
 
-	// TODO: This layout may break some screens, is there a "failsafe" way?
-	if status != nil && status.ClusterInfo != nil {
-		ux.Logger.PrintToUser("Network is Up. Network information:")
-		ux.Logger.PrintToUser("==================================================================================================")
-		ux.Logger.PrintToUser("Healthy: %t", status.ClusterInfo.Healthy)
-		ux.Logger.PrintToUser("Custom VMs healthy: %t", status.ClusterInfo.CustomChainsHealthy)
-		ux.Logger.PrintToUser("Number of nodes: %d", len(status.ClusterInfo.NodeNames))
-		ux.Logger.PrintToUser("Number of custom VMs: %d", len(status.ClusterInfo.CustomChains))
-		ux.Logger.PrintToUser("======================================== Node information ========================================")
-		for n, nodeInfo := range status.ClusterInfo.NodeInfos {
-			ux.Logger.PrintToUser("%s has ID %s and endpoint %s: ", n, nodeInfo.Id, nodeInfo.Uri)
+	watchCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+	go func() {
+		select {
+		case <-sigc:
+			cancel()
+		case <-watchCtx.Done():
 		}
-		ux.Logger.PrintToUser("==================================== Custom VM information =======================================")
-		for _, nodeInfo := range status.ClusterInfo.NodeInfos {
-			for blockchainID := range status.ClusterInfo.CustomChains {
-				ux.Logger.PrintToUser("Endpoint at %s for blockchain %q: %s/ext/bc/%s/rpc", nodeInfo.Name, blockchainID, nodeInfo.GetUri(), blockchainID)
-			}
+	}()
+
+	updates, err := cli.StreamStatus(watchCtx, watchInterval)
+	if err != nil {
+		return err
+	}
+	for clusterInfo := range updates {
+		fmt.Print(clearScreenSeq)
+		ux.Logger.PrintToUser("Status at %s (Ctrl+C to exit)", time.Now().Format("15:04:05"))
+		printClusterStatus(clusterInfo)
+	}
+	return nil
+}
+
+func printClusterStatus(clusterInfo *rpcpb.ClusterInfo) {
+	nodeNames := clusterInfo.GetNodeNames()
+	sort.Strings(nodeNames)
+	blockchainIDs := []string{}
+	for blockchainID := range clusterInfo.CustomChains {
+		blockchainIDs = append(blockchainIDs, blockchainID)
+	}
+	sort.Strings(blockchainIDs)
+	nodeStatuses := getNodeStatuses(clusterInfo, blockchainIDs)
+
+	ux.Logger.PrintToUser("Network %s is Up. Network information:", app.GetLocalNetworkName())
+	ux.Logger.PrintToUser("==================================================================================================")
+	ux.Logger.PrintToUser("Healthy: %t", clusterInfo.Healthy)
+	ux.Logger.PrintToUser("Custom VMs healthy: %t", clusterInfo.CustomChainsHealthy)
+	ux.Logger.PrintToUser("Number of nodes: %d", len(nodeNames))
+	ux.Logger.PrintToUser("Number of custom VMs: %d", len(clusterInfo.CustomChains))
+	ux.Logger.PrintToUser("======================================== Node information ========================================")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Node", "ID", "URI", "PID", "Healthy", "Version"})
+	for _, nodeName := range nodeNames {
+		nodeInfo := clusterInfo.NodeInfos[nodeName]
+		nodeStatus := nodeStatuses[nodeName]
+		pid := "-"
+		if proc, err := binutils.GetNodeProcess(nodeInfo.GetLogDir()); err == nil {
+			pid = strconv.Itoa(int(proc.Pid))
 		}
-	} else {
-		ux.Logger.PrintToUser("No local network running")
+		version := "-"
+		if nodeStatus.Reachable {
+			version = nodeStatus.Version
+		}
+		table.Append([]string{nodeName, nodeInfo.Id, nodeInfo.Uri, pid, formatHealth(nodeStatus.Reachable, nodeStatus.Healthy), version})
 	}
+	table.Render()
 
-	// TODO: verbose output?
-	// ux.Logger.PrintToUser(status.String())
+	if len(blockchainIDs) == 0 {
+		return
+	}
+	subnetNames := subnet.GetLocalSubnetNames(app)
+	ux.Logger.PrintToUser("==================================== Custom VM information =======================================")
+	for _, blockchainID := range blockchainIDs {
+		chainInfo := clusterInfo.CustomChains[blockchainID]
+		subnetName, ok := subnetNames[blockchainID]
+		if !ok {
+			subnetName = chainInfo.ChainName
+		}
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("Subnet %s, VM ID %s, blockchain ID %s", subnetName, chainInfo.VmId, blockchainID)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Node", "RPC URL", "Bootstrapped", "Healthy"})
+		for _, nodeName := range nodeNames {
+			nodeInfo := clusterInfo.NodeInfos[nodeName]
+			nodeStatus := nodeStatuses[nodeName]
+			chainStatus := nodeStatus.Chains[blockchainID]
+			table.Append([]string{
+				nodeName,
+				fmt.Sprintf("%s/ext/bc/%s/rpc", nodeInfo.GetUri(), blockchainID),
+				formatHealth(nodeStatus.Reachable, chainStatus.Bootstrapped),
+				formatHealth(nodeStatus.Reachable, chainStatus.Healthy),
+			})
+		}
+		table.Render()
+	}
+}
 
-	return nil
+// queries all nodes concurrently, so unreachable nodes only delay the
+// output by a single timeout
+func getNodeStatuses(clusterInfo *rpcpb.ClusterInfo, blockchainIDs []string) map[string]localnetworkinterface.NodeStatus {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		statuses = map[string]localnetworkinterface.NodeStatus{}
+	)
+	for nodeName, nodeInfo := range clusterInfo.NodeInfos {
+		wg.Add(1)
+		go func(nodeName string, uri string) {
+			defer wg.Done()
+			status := localnetworkinterface.GetNodeStatus(uri, blockchainIDs)
+			mu.Lock()
+			statuses[nodeName] = status
+			mu.Unlock()
+		}(nodeName, nodeInfo.Uri)
+	}
+	wg.Wait()
+	return statuses
+}
+
+func formatHealth(reachable bool, ok bool) string {
+	switch {
+	case !reachable:
+		return logging.Yellow.Wrap("unreachable")
+	case ok:
+		return logging.Green.Wrap("yes")
+	default:
+		return logging.Red.Wrap("no")
+	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-network-runner/local"
 	"github.com/ava-labs/avalanche-network-runner/server"
@@ -34,7 +35,16 @@ default snapshot with network start.`,
 }
 
 func StopNetwork(*cobra.Command, []string) error {
-	cli, err := binutils.NewGRPCClient()
+	if err := subnet.ValidateSnapshotName(snapshotName); err != nil {
+		return err
+	}
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	cli, err := binutils.NewGRPCClient(app)
 	if err != nil {
 		return err
 	}
@@ -54,8 +64,7 @@ func StopNetwork(*cobra.Command, []string) error {
 		}
 	}
 
-	_, err = cli.SaveSnapshot(ctx, snapshotName)
-	if err != nil {
+	if err := subnet.SaveSnapshot(ctx, app, cli, snapshotName); err != nil {
 		return fmt.Errorf("failed to stop network with a snapshot: %w", err)
 	}
 	ux.Logger.PrintToUser("Network stopped successfully.")
@@ -0,0 +1,243 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/localnetworkinterface"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	upgradeSnapshotPrefix = "avalanchego-upgrade"
+	timestampFormat       = "20060102150405"
+)
+
+var (
+	errIncompatibleVMs = errors.New("deployed VMs are not compatible with the target avalanchego version")
+
+	upgradeAvagoVersion string
+	rollingUpgrade      bool
+)
+
+// avalanche network upgrade
+func newUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade avalanchego on the running local network",
+		Long: `The network upgrade command switches the avalanchego version of the running local network,
+preserving the state of all deployed Subnets.
+
+The command first checks that the RPC protocol version of every deployed VM is compatible
+with the target avalanchego version. By default, it then saves a snapshot of the network
+and restarts all nodes from it with the new binary. With --rolling, nodes are instead
+restarted one at a time, waiting for the network to become healthy after each one.`,
+
+		RunE:         upgradeNetwork,
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&upgradeAvagoVersion, "avalanchego-version", "", "avalanchego version to upgrade to (ex: v1.9.4), or latest")
+	cmd.Flags().BoolVar(&rollingUpgrade, "rolling", false, "upgrade one node at a time instead of restarting the whole network")
+
+	return cmd
+}
+
+func upgradeNetwork(*cobra.Command, []string) error {
+	lock, err := app.LockLocalNetwork()
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+
+	if upgradeAvagoVersion == "" {
+		return errors.New("--avalanchego-version is required")
+	}
+
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	status, err := cli.Status(ctx)
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			ux.Logger.PrintToUser("No local network running. Please start the network first.")
+		}
+		return err
+	}
+
+	targetVersion := upgradeAvagoVersion
+	if targetVersion == "latest" {
+		targetVersion, err = app.Downloader.GetLatestReleaseVersion(binutils.GetGithubLatestReleaseURL(
+			constants.AvaLabsOrg,
+			constants.AvalancheGoRepoName,
+		))
+		if err != nil {
+			return err
+		}
+	}
+
+	currentVersion, _, running, err := localnetworkinterface.NewStatusChecker(app.GetLocalAPIEndpoint()).GetCurrentNetworkVersion()
+	if err != nil {
+		return err
+	}
+	if running && currentVersion == targetVersion {
+		ux.Logger.PrintToUser("Local network is already running avalanchego %s", targetVersion)
+		return nil
+	}
+
+	if err := checkDeployedVMsCompatibility(status.ClusterInfo, targetVersion); err != nil {
+		return err
+	}
+
+	avagoDir, err := binutils.SetupAvalanchego(app, targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed installing avalanchego %s: %w", targetVersion, err)
+	}
+	avalancheGoBinPath := filepath.Join(avagoDir, "avalanchego")
+
+	if rollingUpgrade {
+		err = rollingRestart(cli, status.ClusterInfo, avalancheGoBinPath)
+	} else {
+		err = snapshotRestart(cli, avalancheGoBinPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	clusterInfo, err := subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	if err := subnet.UpdateLocalAPIEndpoint(app, clusterInfo); err != nil {
+		return err
+	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
+
+	fmt.Println()
+	ux.Logger.PrintToUser("Local network upgraded to avalanchego %s", targetVersion)
+	if subnet.HasEndpoints(clusterInfo) {
+		ux.PrintTableEndpoints(clusterInfo)
+	}
+	return nil
+}
+
+// checks that every VM deployed on the local network uses the same
+// RPC protocol version as [avagoVersion]
+func checkDeployedVMsCompatibility(clusterInfo *rpcpb.ClusterInfo, avagoVersion string) error {
+	avagoRPCVersion, err := vm.GetAvalancheGoRPCProtocolVersion(app, avagoVersion, constants.AvalancheGoCompatibilityURL)
+	if err != nil {
+		return fmt.Errorf("failed getting RPC protocol version of avalanchego %s: %w", avagoVersion, err)
+	}
+
+	incompatible := false
+	for _, chainInfo := range clusterInfo.GetCustomChains() {
+		subnetName := chainInfo.ChainName
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			app.Log.Debug("failed loading sidecar of deployed chain", zap.String("chain", subnetName), zap.Error(err))
+			ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+				"Unable to find the configuration of chain %s, skipping its compatibility check", subnetName)))
+			continue
+		}
+		if sc.VM == models.CustomVM {
+			ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+				"Subnet %s uses a custom VM, make sure it supports RPC protocol version %d", subnetName, avagoRPCVersion)))
+			continue
+		}
+		rpcVersion, err := vm.GetRPCProtocolVersion(app, sc.VM, sc.VMVersion)
+		if err != nil {
+			return fmt.Errorf("failed getting RPC protocol version for %s %s: %w", sc.VM, sc.VMVersion, err)
+		}
+		if rpcVersion != avagoRPCVersion {
+			ux.Logger.PrintToUser(logging.Red.Wrap(fmt.Sprintf(
+				"Subnet %s runs %s %s with RPC protocol version %d, but avalanchego %s uses version %d",
+				subnetName, sc.VM, sc.VMVersion, rpcVersion, avagoVersion, avagoRPCVersion)))
+			incompatible = true
+		}
+	}
+	if incompatible {
+		ux.Logger.PrintToUser("Upgrade the VMs with `avalanche subnet upgrade vm --local` before upgrading avalanchego")
+		return errIncompatibleVMs
+	}
+	return nil
+}
+
+// saves a temporary snapshot, which stops the network, and loads it back
+// with all nodes using [avalancheGoBinPath]
+func snapshotRestart(cli client.Client, avalancheGoBinPath string) error {
+	ctx := binutils.GetAsyncContext()
+
+	snapName := upgradeSnapshotPrefix + constants.TmpSnapshotInfix + time.Now().Format(timestampFormat)
+	app.Log.Debug("saving temporary snapshot for avalanchego upgrade", zap.String("snapshot-name", snapName))
+	if err := subnet.SaveSnapshot(ctx, app, cli, snapName); err != nil {
+		return fmt.Errorf("failed saving network snapshot: %w", err)
+	}
+
+	outputDirPrefix := path.Join(app.GetRunDir(), "restart")
+	outputDir, err := utils.MkDirWithTimestamp(outputDirPrefix)
+	if err != nil {
+		return err
+	}
+
+	loadSnapshotOpts := []client.OpOption{
+		client.WithExecPath(avalancheGoBinPath),
+		client.WithRootDataDir(outputDir),
+		client.WithReassignPortsIfUsed(true),
+		client.WithPluginDir(app.GetPluginsDir()),
+	}
+	configStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return err
+	}
+	if configStr != "" {
+		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
+	}
+
+	ux.Logger.PrintToUser("Restarting network from snapshot %s...", snapName)
+	if _, err := cli.LoadSnapshot(ctx, snapName, loadSnapshotOpts...); err != nil {
+		return fmt.Errorf("failed restarting network from snapshot %s: %w", snapName, err)
+	}
+	return nil
+}
+
+// restarts the nodes one at a time with [avalancheGoBinPath], waiting for
+// the network to be healthy after each restart
+func rollingRestart(cli client.Client, clusterInfo *rpcpb.ClusterInfo, avalancheGoBinPath string) error {
+	ctx := binutils.GetAsyncContext()
+
+	nodeNames := clusterInfo.GetNodeNames()
+	sort.Strings(nodeNames)
+	for i, nodeName := range nodeNames {
+		ux.Logger.PrintToUser("Upgrading node %s (%d/%d)...", nodeName, i+1, len(nodeNames))
+		if _, err := cli.RestartNode(ctx, nodeName, client.WithExecPath(avalancheGoBinPath)); err != nil {
+			return fmt.Errorf("failed restarting node %s: %w", nodeName, err)
+		}
+		if _, err := subnet.WaitForHealthy(ctx, cli); err != nil {
+			return fmt.Errorf("failed waiting for network to become healthy after restarting node %s: %w", nodeName, err)
+		}
+	}
+	return nil
+}
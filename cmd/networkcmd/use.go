@@ -0,0 +1,89 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network use
+func newUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use [networkName]",
+		Short: "Select the local network targeted by local commands",
+		Long: `The network use command selects which of the local networks created with
+network start --name is targeted by the network commands and by local deployments.
+
+Without arguments, it lists the local networks and which one is selected.`,
+
+		RunE:         useNetwork,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+	}
+}
+
+func useNetwork(_ *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return printLocalNetworks()
+	}
+	name := args[0]
+	names, err := app.GetLocalNetworkNames()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, n := range names {
+		if n == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		ux.Logger.PrintToUser("Local network %s does not exist yet, create it with `avalanche network start --name %s`", name, name)
+		return nil
+	}
+	if err := app.SelectLocalNetwork(name); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Local network %s selected", name)
+	return nil
+}
+
+func printLocalNetworks() error {
+	names, err := app.GetLocalNetworkNames()
+	if err != nil {
+		return err
+	}
+	selected := app.GetLocalNetworkName()
+	procChecker := binutils.NewProcessChecker()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Network", "Selected", "Backend running", "gRPC port", "API endpoint"})
+	for _, name := range names {
+		app.UseLocalNetwork(name)
+		ln, err := app.LoadLocalNetwork()
+		if err != nil {
+			app.UseLocalNetwork(selected)
+			return err
+		}
+		running, err := procChecker.IsServerProcessRunning(app)
+		if err != nil {
+			app.UseLocalNetwork(selected)
+			return err
+		}
+		selectedMark := ""
+		if name == selected {
+			selectedMark = "*"
+		}
+		table.Append([]string{name, selectedMark, strconv.FormatBool(running), strconv.Itoa(ln.GRPCPort), ln.APIEndpoint})
+	}
+	app.UseLocalNetwork(selected)
+	table.Render()
+	return nil
+}
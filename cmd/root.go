@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/ava-labs/avalanche-cli/cmd/backendcmd"
+	"github.com/ava-labs/avalanche-cli/cmd/binariescmd"
 	"github.com/ava-labs/avalanche-cli/cmd/keycmd"
 	"github.com/ava-labs/avalanche-cli/cmd/networkcmd"
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
@@ -33,6 +34,9 @@ var (
 	logLevel string
 	Version  = ""
 	cfgFile  string
+	offline  bool
+
+	requireChecksums bool
 )
 
 func NewRootCmd() *cobra.Command {
@@ -54,11 +58,17 @@ in with avalanche subnet create myNewSubnet.`,
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.avalanche-cli.json)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "ERROR", "log level for the application")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false,
+		"resolve versions only from installed binaries and cached compatibility data, without network access")
+	rootCmd.PersistentFlags().BoolVar(&requireChecksums, "require-checksums", false,
+		"refuse to install binaries whose releases publish no checksums (as avalanchego). Also set by "+
+			constants.RequireChecksumsEnvVarName)
 
 	// add sub commands
 	rootCmd.AddCommand(subnetcmd.NewCmd(app))
 	rootCmd.AddCommand(networkcmd.NewCmd(app))
 	rootCmd.AddCommand(keycmd.NewCmd(app))
+	rootCmd.AddCommand(binariescmd.NewCmd(app))
 
 	// add hidden backend command
 	rootCmd.AddCommand(backendcmd.NewCmd(app))
@@ -79,6 +89,8 @@ func createApp(cmd *cobra.Command, _ []string) error {
 	}
 	cf := config.New()
 	app.Setup(baseDir, log, cf, prompts.NewPrompter(), application.NewDownloader())
+	app.Version = Version
+	app.RequireChecksums = requireChecksums || os.Getenv(constants.RequireChecksumsEnvVarName) != ""
 
 	// Setup APM, skip if running a hidden command
 	if !cmd.Hidden {
@@ -95,6 +107,13 @@ func createApp(cmd *cobra.Command, _ []string) error {
 
 	initConfig()
 
+	// the release mirror is read from the config file
+	downloader, err := application.NewConfiguredDownloader(baseDir, cf.LoadReleaseMirror(), offline)
+	if err != nil {
+		return err
+	}
+	app.Downloader = downloader
+
 	if err := migrations.RunMigrations(app); err != nil {
 		return err
 	}
@@ -185,7 +204,7 @@ func setupLogging(baseDir string) (logging.Logger, error) {
 	config.MaxAge = constants.RetainOldFiles
 
 	factory := logging.NewFactory(config)
-	log, err := factory.Make("avalanche")
+	log, err := factory.Make(constants.CLILogName)
 	if err != nil {
 		factory.Close()
 		return nil, fmt.Errorf("failed setting up logging, exiting: %w", err)
@@ -135,12 +135,12 @@ func addValidator(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	subnetID := sc.Networks[network.String()].SubnetID
+	subnetID := sc.Networks[app.GetNetworkKey(network)].SubnetID
 	if subnetID == ids.Empty {
 		return errNoSubnetID
 	}
 
-	controlKeys, threshold, err := subnet.GetOwners(network, subnetID)
+	controlKeys, threshold, err := subnet.GetOwners(app, network, subnetID)
 	if err != nil {
 		return err
 	}
@@ -247,7 +247,7 @@ func getMaxValidationTime(network models.Network, nodeID ids.NodeID, startTime t
 		uri = constants.MainnetAPIEndpoint
 	case models.Local:
 		// used for E2E testing of public related paths
-		uri = constants.LocalAPIEndpoint
+		uri = app.GetLocalAPIEndpoint()
 	default:
 		return 0, fmt.Errorf("unsupported public network")
 	}
@@ -26,6 +26,12 @@ func newDeleteCmd() *cobra.Command {
 func deleteSubnet(_ *cobra.Command, args []string) error {
 	// TODO sanitize this input
 	subnetName := args[0]
+
+	lock, err := app.LockSubnet(subnetName)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
 	subnetDir := filepath.Join(app.GetSubnetDir(), subnetName)
 
 	customVMPath := app.GetCustomVMPath(subnetName)
@@ -49,6 +49,8 @@ var (
 	controlKeys              []string
 	subnetAuthKeys           []string
 	userProvidedAvagoVersion string
+	userProvidedAvagoPath    string
+	userProvidedVMPath       string
 	outputTxPath             string
 	useLedger                bool
 	ledgerAddresses          []string
@@ -57,6 +59,9 @@ var (
 	errMutuallyExlusiveControlKeys = errors.New("--control-keys and --same-control-key are mutually exclusive")
 	ErrMutuallyExlusiveKeyLedger   = errors.New("--key and --ledger,--ledger-addrs are mutually exclusive")
 	ErrStoredKeyOnMainnet          = errors.New("--key is not available for mainnet operations")
+	errMutuallyExlusiveAvago       = errors.New("--avalanchego-version and --avalanchego-path are mutually exclusive")
+	errLocalBinariesNotLocal       = errors.New("--avalanchego-path and --vm-path are only available for local deploys")
+	errVMPathUnsupported           = errors.New("--vm-path is only available for Subnet-EVM and SpacesVM subnets")
 )
 
 // avalanche subnet deploy
@@ -73,7 +78,14 @@ attempts to deploy the same Subnet to the same network (local, Fuji, Mainnet) ar
 allowed. If you'd like to redeploy a Subnet locally for testing, you must first call
 avalanche network clean to reset all deployed chain state. Subsequent local deploys
 redeploy the chain with fresh state. You can deploy the same Subnet to multiple networks,
-so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
+so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.
+
+The --avalanchego-path and --vm-path flags deploy locally with locally built avalanchego and
+Subnet-EVM or SpacesVM binaries instead of released versions. Their reported versions and
+RPC protocol versions are checked against the compatibility data, and their paths are
+recorded in the Subnet configuration. Later local deploys use the recorded binaries, unless
+other ones are given. Use --avalanchego-version to deploy with a released avalanchego again,
+and avalanche subnet upgrade vm to switch the VM back to a released version.`,
 		SilenceUsage: true,
 		RunE:         deploySubnet,
 		Args:         cobra.ExactArgs(1),
@@ -83,6 +95,8 @@ so you can take your locally tested Subnet and deploy it on Fuji or Mainnet.`,
 	cmd.Flags().BoolVarP(&deployTestnet, "fuji", "f", false, "deploy to fuji (alias to `testnet`")
 	cmd.Flags().BoolVarP(&deployMainnet, "mainnet", "m", false, "deploy to mainnet")
 	cmd.Flags().StringVar(&userProvidedAvagoVersion, "avalanchego-version", "latest", "use this version of avalanchego (ex: v1.17.12)")
+	cmd.Flags().StringVar(&userProvidedAvagoPath, "avalanchego-path", "", "use the locally built avalanchego binary at this path [local deploy only]")
+	cmd.Flags().StringVar(&userProvidedVMPath, "vm-path", "", "use the locally built Subnet-EVM or SpacesVM binary at this path [local deploy only]")
 	cmd.Flags().StringVarP(&keyName, "key", "k", "", "select the key to use [fuji deploy only]")
 	cmd.Flags().BoolVarP(&sameControlKey, "same-control-key", "s", false, "use creation key as control key")
 	cmd.Flags().Uint32Var(&threshold, "threshold", 0, "required number of control key signatures to make subnet changes")
@@ -125,7 +139,7 @@ func getChainsInSubnet(subnetName string) ([]string, error) {
 }
 
 // deploySubnet is the cobra command run for deploying subnets
-func deploySubnet(_ *cobra.Command, args []string) error {
+func deploySubnet(cmd *cobra.Command, args []string) error {
 	chains, err := validateSubnetNameAndGetChains(args)
 	if err != nil {
 		return err
@@ -164,6 +178,10 @@ func deploySubnet(_ *cobra.Command, args []string) error {
 		network = models.Mainnet
 	}
 
+	if userProvidedAvagoPath != "" && userProvidedAvagoVersion != "latest" {
+		return errMutuallyExlusiveAvago
+	}
+
 	if network == models.Undefined {
 		// no flag was set, prompt user
 		networkStr, err := app.Prompt.CaptureList(
@@ -176,6 +194,24 @@ func deploySubnet(_ *cobra.Command, args []string) error {
 		network = models.NetworkFromString(networkStr)
 	}
 
+	if network != models.Local && (userProvidedAvagoPath != "" || userProvidedVMPath != "") {
+		return errLocalBinariesNotLocal
+	}
+
+	if network == models.Local {
+		release, err := app.LockLocalNetworkAndSubnet(chain)
+		if err != nil {
+			return err
+		}
+		defer release()
+	} else {
+		lock, err := app.LockSubnet(chain)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+	}
+
 	// deploy based on chosen network
 	ux.Logger.PrintToUser("Deploying %s to %s", chains, network.String())
 	chainGenesis, err := app.LoadRawGenesis(chain)
@@ -215,36 +251,62 @@ func deploySubnet(_ *cobra.Command, args []string) error {
 	case models.Local:
 		app.Log.Debug("Deploy local")
 
+		// redeploy with the locally built binaries of the last deploy, if not told otherwise
+		if userProvidedVMPath == "" && sidecar.VMPath != "" {
+			ux.Logger.PrintToUser("Using the %s binary of the last local deploy, at %s", sidecar.VM, sidecar.VMPath)
+			userProvidedVMPath = sidecar.VMPath
+		}
+		if userProvidedAvagoPath == "" && sidecar.AvalancheGoPath != "" && !cmd.Flags().Changed("avalanchego-version") {
+			ux.Logger.PrintToUser("Using the avalanchego binary of the last local deploy, at %s", sidecar.AvalancheGoPath)
+			userProvidedAvagoPath = sidecar.AvalancheGoPath
+		}
+
 		// copy vm binary to the expected location, first downloading it if necessary
 		var vmBin string
-		switch sidecar.VM {
-		case models.SubnetEvm:
-			vmBin, err = binutils.SetupSubnetEVM(app, sidecar.VMVersion)
+		rpcVersion := sidecar.RPCVersion
+		if userProvidedVMPath != "" {
+			vmBin, rpcVersion, err = getLocalVMBinary(sidecar)
 			if err != nil {
-				return fmt.Errorf("failed to install subnet-evm: %w", err)
+				return err
+			}
+		} else {
+			switch sidecar.VM {
+			case models.SubnetEvm:
+				vmBin, err = binutils.SetupSubnetEVM(app, sidecar.VMVersion)
+				if err != nil {
+					return fmt.Errorf("failed to install subnet-evm: %w", err)
+				}
+			case models.SpacesVM:
+				vmBin, err = binutils.SetupSpacesVM(app, sidecar.VMVersion)
+				if err != nil {
+					return fmt.Errorf("failed to install spacesvm: %w", err)
+				}
+			case models.CustomVM:
+				vmBin = binutils.SetupCustomBin(app, chain)
+			default:
+				return fmt.Errorf("unknown vm: %s", sidecar.VM)
 			}
-		case models.SpacesVM:
-			vmBin, err = binutils.SetupSpacesVM(app, sidecar.VMVersion)
+		}
+
+		avagoPath := ""
+		if userProvidedAvagoPath != "" {
+			avagoPath, userProvidedAvagoVersion, err = getLocalAvalancheGoBinary(sidecar, rpcVersion)
 			if err != nil {
-				return fmt.Errorf("failed to install spacesvm: %w", err)
+				return err
 			}
-		case models.CustomVM:
-			vmBin = binutils.SetupCustomBin(app, chain)
-		default:
-			return fmt.Errorf("unknown vm: %s", sidecar.VM)
 		}
 
 		// skip rpc check if using custom vm
 		if sidecar.VM != models.CustomVM {
 			// check if selected version matches what is currently running
-			nc := localnetworkinterface.NewStatusChecker()
-			userProvidedAvagoVersion, err = checkForInvalidDeployAndGetAvagoVersion(nc, sidecar.RPCVersion)
+			nc := localnetworkinterface.NewStatusChecker(app.GetLocalAPIEndpoint())
+			userProvidedAvagoVersion, err = checkForInvalidDeployAndGetAvagoVersion(nc, rpcVersion)
 			if err != nil {
 				return err
 			}
 		}
 
-		deployer := subnet.NewLocalDeployer(app, userProvidedAvagoVersion, vmBin)
+		deployer := subnet.NewLocalDeployer(app, userProvidedAvagoVersion, avagoPath, vmBin)
 		subnetID, blockchainID, err := deployer.DeployToLocalNetwork(chain, chainGenesis, genesisPath)
 		if err != nil {
 			if deployer.BackendStartedHere() {
@@ -254,6 +316,11 @@ func deploySubnet(_ *cobra.Command, args []string) error {
 			}
 			return err
 		}
+		sidecar.VMPath = ""
+		if userProvidedVMPath != "" {
+			sidecar.VMPath = vmBin
+		}
+		sidecar.AvalancheGoPath = avagoPath
 		return app.UpdateSidecarNetworks(&sidecar, network, subnetID, blockchainID)
 
 	case models.Fuji:
@@ -577,7 +644,7 @@ func SaveNotFullySignedTx(
 	outputTxPath string,
 	forceOverwrite bool,
 ) error {
-	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, network, subnetID)
+	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(app, tx, network, subnetID)
 	if err != nil {
 		return err
 	}
@@ -760,6 +827,48 @@ func PrintDeployResults(chain string, subnetID ids.ID, blockchainID ids.ID, isFu
 	return nil
 }
 
+// returns the absolute path of the locally built VM given by --vm-path, and its RPC
+// protocol version, checking it's a build of the VM of [sc]
+func getLocalVMBinary(sc models.Sidecar) (string, int, error) {
+	if sc.VM != models.SubnetEvm && sc.VM != models.SpacesVM {
+		return "", 0, errVMPathUnsupported
+	}
+	vmPath, err := filepath.Abs(userProvidedVMPath)
+	if err != nil {
+		return "", 0, err
+	}
+	vmVersion, rpcVersion, err := vm.GetLocalVMVersion(app, sc.VM, vmPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed checking %s binary %s: %w", sc.VM, vmPath, err)
+	}
+	ux.Logger.PrintToUser("Using %s %s built at %s (RPC protocol version %d)", sc.VM, vmVersion, vmPath, rpcVersion)
+	if rpcVersion != sc.RPCVersion {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"The configured %s %s uses RPC protocol version %d, avalanchego is chosen for version %d instead",
+			sc.VM, sc.VMVersion, sc.RPCVersion, rpcVersion)))
+	}
+	return vmPath, rpcVersion, nil
+}
+
+// returns the absolute path and the version of the locally built avalanchego given by
+// --avalanchego-path, checking it runs VMs of RPC protocol version [rpcVersion]
+func getLocalAvalancheGoBinary(sc models.Sidecar, rpcVersion int) (string, string, error) {
+	avagoPath, err := filepath.Abs(userProvidedAvagoPath)
+	if err != nil {
+		return "", "", err
+	}
+	avagoVersion, avagoRPCVersion, err := vm.GetLocalAvalancheGoVersion(app, avagoPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed checking avalanchego binary %s: %w", avagoPath, err)
+	}
+	ux.Logger.PrintToUser("Using avalanchego %s built at %s (RPC protocol version %d)", avagoVersion, avagoPath, avagoRPCVersion)
+	if sc.VM != models.CustomVM && avagoRPCVersion != rpcVersion {
+		return "", "", fmt.Errorf("%w: avalanchego %s uses RPC protocol version %d but the %s of subnet %s uses %d",
+			vm.ErrRPCVersionMismatch, avagoPath, avagoRPCVersion, sc.VM, sc.Name, rpcVersion)
+	}
+	return avagoPath, avagoVersion, nil
+}
+
 // Determines the appropriate version of avalanchego to run with. Returns an error if
 // that version conflicts with the current deployment.
 func checkForInvalidDeployAndGetAvagoVersion(network localnetworkinterface.StatusChecker, configuredRPCVersion int) (string, error) {
@@ -73,6 +73,12 @@ func printDetails(genesis core.Genesis, sc models.Sidecar) {
 	table.Append([]string{"ChainID", genesis.Config.ChainID.String()})
 	table.Append([]string{"Token Name", app.GetTokenName(sc.Subnet)})
 	table.Append([]string{"VM Version", sc.VMVersion})
+	if sc.VMPath != "" {
+		table.Append([]string{"Local VM Binary", sc.VMPath})
+	}
+	if sc.AvalancheGoPath != "" {
+		table.Append([]string{"Local AvalancheGo Binary", sc.AvalancheGoPath})
+	}
 	if sc.ImportedVMID != "" {
 		table.Append([]string{"VM ID", sc.ImportedVMID})
 	} else {
@@ -125,7 +125,7 @@ func joinCmd(_ *cobra.Command, args []string) error {
 
 	networkLower := strings.ToLower(network.String())
 
-	subnetID := sc.Networks[network.String()].SubnetID
+	subnetID := sc.Networks[app.GetNetworkKey(network)].SubnetID
 	if subnetID == ids.Empty {
 		return errNoSubnetID
 	}
@@ -208,7 +208,7 @@ but until the node is whitelisted, it will not be able to validate this subnet.`
 	// or, pluginDir was set but not avagoConfigPath
 	// if **both** flags were set, this will be skipped...
 	if avagoConfigPath == "" {
-		avagoConfigPath, err = plugins.FindAvagoConfigPath()
+		avagoConfigPath, err = plugins.FindAvagoConfigPath(app)
 		if err != nil {
 			return err
 		}
@@ -314,7 +314,7 @@ func isNodeValidatingSubnet(subnetID ids.ID, network models.Network) (bool, erro
 	case models.Mainnet:
 		api = constants.MainnetAPIEndpoint
 	case models.Local:
-		api = constants.LocalAPIEndpoint
+		api = app.GetLocalAPIEndpoint()
 	default:
 		return false, fmt.Errorf("network not supported")
 	}
@@ -75,7 +75,7 @@ func stats(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	subnetID := sc.Networks[network.String()].SubnetID
+	subnetID := sc.Networks[app.GetNetworkKey(network)].SubnetID
 	if subnetID == ids.Empty {
 		return errors.New("no subnetID found for the provided subnet name; has this subnet actually been deployed to this network?")
 	}
@@ -277,12 +277,16 @@ func buildCurrentValidatorStats(pClient platformvm.Client, infoClient info.Clien
 func findAPIEndpoint(network models.Network) (platformvm.Client, info.Client) {
 	var i info.Client
 
-	// first try local node
+	// first try local node, which for the local network is the one of the target local network
+	localEndpoint := constants.LocalAPIEndpoint
+	if network == models.Local {
+		localEndpoint = app.GetLocalAPIEndpoint()
+	}
 	ctx := context.Background()
-	c := platformvm.NewClient(constants.LocalAPIEndpoint)
+	c := platformvm.NewClient(localEndpoint)
 	_, err := c.GetHeight(ctx)
 	if err == nil {
-		i = info.NewClient(constants.LocalAPIEndpoint)
+		i = info.NewClient(localEndpoint)
 		// try calling it to make sure it actually worked
 		_, _, err := i.GetNodeID(ctx)
 		if err == nil {
@@ -309,7 +313,7 @@ func findAPIEndpoint(network models.Network) (platformvm.Client, info.Client) {
 	_, err = c.GetHeight(ctx)
 	if err == nil {
 		// also try to get a local client
-		i = info.NewClient(constants.LocalAPIEndpoint)
+		i = info.NewClient(localEndpoint)
 	}
 	return c, i
 }
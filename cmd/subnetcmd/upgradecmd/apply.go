@@ -3,36 +3,40 @@
 package upgradecmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/plugins"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	ANRclient "github.com/ava-labs/avalanche-network-runner/client"
 	"github.com/ava-labs/avalanche-network-runner/server"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/subnet-evm/params"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
 
-const (
-	timestampFormat  = "20060102150405"
-	tmpSnapshotInfix = "-tmp-"
-)
+const timestampFormat = "20060102150405"
 
 var (
 	ErrNetworkNotStartedOutput = "No local network running. Please start the network first."
 	ErrSubnetNotDeployedOutput = "Looks like this subnet has not been deployed to a local network yet."
 
-	errNotYetImplemented    = errors.New("not yet implemented")
 	errSubnetNotYetDeployed = errors.New("subnet not yet deployed")
+	errNoChainConfigDir     = errors.New("avalanchego chain config dir not found")
+
+	avagoChainConfigDir string
 )
 
 // avalanche subnet upgrade apply
@@ -50,6 +54,8 @@ func newUpgradeApplyCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&useFuji, "fuji", false, "apply upgrade existing `fuji` deployment (alias for `testnet`)")
 	cmd.Flags().BoolVar(&useFuji, "testnet", false, "apply upgrade existing `testnet` deployment (alias for `fuji`)")
 	cmd.Flags().BoolVar(&useMainnet, "mainnet", false, "apply upgrade existing `mainnet` deployment")
+	cmd.Flags().StringVar(&avagoChainConfigDir, "avalanchego-chain-config-dir", "",
+		"avalanchego's chain config file directory (fuji and mainnet only, autodetected if not given)")
 
 	return cmd
 }
@@ -71,14 +77,26 @@ func applyCmd(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	release, err := lockForUpgrade(subnetName, networkToUpgrade)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// reload the sidecar, as it may have changed while waiting for the locks
+	sc, err = app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("unable to load sidecar: %w", err)
+	}
+
 	switch networkToUpgrade {
 	// update a locally running network
 	case localDeployment:
 		return applyLocalNetworkUpgrade(subnetName, sc)
 	case fujiDeployment:
-		return errNotYetImplemented
+		return applyPublicNetworkUpgrade(subnetName, models.Fuji.String(), sc)
 	case mainnetDeployment:
-		return errNotYetImplemented
+		return applyPublicNetworkUpgrade(subnetName, models.Mainnet.String(), sc)
 	}
 
 	return nil
@@ -99,7 +117,7 @@ func applyCmd(_ *cobra.Command, args []string) error {
 // save a snapshot, and to load the snapshot with the upgrade
 func applyLocalNetworkUpgrade(subnetName string, sc models.Sidecar) error {
 	// if there's no entry in the Sidecar, we assume there hasn't been a deploy yet
-	networkKey := models.Local.String()
+	networkKey := app.GetNetworkKey(models.Local)
 	if sc.Networks[networkKey] == (models.NetworkData{}) {
 		return subnetNotYetDeployed()
 	}
@@ -129,7 +147,7 @@ func applyLocalNetworkUpgrade(subnetName string, sc models.Sidecar) error {
 		return err
 	}
 
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(app)
 	if err != nil {
 		ux.Logger.PrintToUser(ErrNetworkNotStartedOutput)
 		return err
@@ -168,9 +186,9 @@ func applyLocalNetworkUpgrade(subnetName string, sc models.Sidecar) error {
 	}
 
 	// save a temporary snapshot
-	snapName := subnetName + tmpSnapshotInfix + time.Now().Format(timestampFormat)
+	snapName := subnetName + constants.TmpSnapshotInfix + time.Now().Format(timestampFormat)
 	app.Log.Debug("saving temporary snapshot for upgrade bytes", zap.String("snapshot-name", snapName))
-	_, err = cli.SaveSnapshot(ctx, snapName)
+	err = subnet.SaveSnapshot(ctx, app, cli, snapName)
 	if err != nil {
 		return err
 	}
@@ -191,6 +209,7 @@ func applyLocalNetworkUpgrade(subnetName string, sc models.Sidecar) error {
 	if err != nil {
 		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
 	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
 
 	fmt.Println()
 	if subnet.HasEndpoints(clusterInfo) {
@@ -204,25 +223,115 @@ func applyLocalNetworkUpgrade(subnetName string, sc models.Sidecar) error {
 		ux.Logger.PrintToUser("The next upgrade will go into effect %s", time.Unix(nextUpgrade, 0).Local().Format(constants.TimeParseLayout))
 		ux.PrintTableEndpoints(clusterInfo)
 
-		// it seems all went well this far, now we try to write/update the lock file
-		// if this fails, we probably don't want to cause an error to the user?
-		// so we are silently failing, just write a log entry
-		wrapper := params.UpgradeConfig{
-			PrecompileUpgrades: precmpUpgrades,
+		writeLockFile(subnetName, precmpUpgrades)
+		return nil
+	}
+
+	return errors.New("unexpected network size of zero nodes")
+}
+
+// applyPublicNetworkUpgrade writes the upgrade bytes into the chain config dir
+// of the avalanchego node running on this machine, for a subnet deployed on a
+// public network. The node must be restarted for the upgrade to be loaded
+// * if upgrade bytes were never written to the node, write them
+// * if already written and the same, print info and do nothing
+// * if already written and different, the new upgrade bytes must contain the
+// *   old ones (append-only), in which case they get written
+func applyPublicNetworkUpgrade(subnetName, networkKey string, sc models.Sidecar) error {
+	if sc.Networks[networkKey] == (models.NetworkData{}) {
+		return subnetNotYetDeployed()
+	}
+	blockchainID := sc.Networks[networkKey].BlockchainID
+	if blockchainID == ids.Empty {
+		return errors.New(
+			"failed to find deployment information about this subnet in state - aborting")
+	}
+
+	netUpgradeBytes, err := app.ReadUpgradeFile(subnetName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			ux.Logger.PrintToUser("No file with upgrade specs for the given subnet has been found")
+			ux.Logger.PrintToUser("You may need to first create it with the `avalanche subnet upgrade generate` command or import it")
+			ux.Logger.PrintToUser("Aborting this command. No changes applied")
 		}
-		jsonBytes, err := json.Marshal(wrapper)
+		return err
+	}
+	lockUpgradeBytes, err := app.ReadLockUpgradeFile(subnetName)
+	if err != nil {
+		// if the file doesn't exist, that's ok
+		if !os.IsNotExist(err) {
+			return err
+		}
+	}
+	precmpUpgrades, err := validateUpgradeBytes(netUpgradeBytes, lockUpgradeBytes)
+	if err != nil {
+		return err
+	}
+
+	chainConfigDir := avagoChainConfigDir
+	if chainConfigDir == "" {
+		chainConfigDir, err = plugins.FindAvagoChainConfigDir(app)
 		if err != nil {
-			app.Log.Debug("failed to marshaling upgrades lock file content", zap.Error(err))
+			return err
+		}
+	}
+	if chainConfigDir == "" {
+		ux.Logger.PrintToUser("Please provide it with --avalanchego-chain-config-dir")
+		return errNoChainConfigDir
+	}
+	ux.Logger.PrintToUser("Using chain config dir %s", chainConfigDir)
+
+	upgradeBytesPath := filepath.Join(chainConfigDir, blockchainID.String(), constants.UpgradeBytesFileName)
+	nodeUpgradeBytes, err := os.ReadFile(upgradeBytesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(nodeUpgradeBytes) > 0 {
+		if bytes.Equal(nodeUpgradeBytes, netUpgradeBytes) {
+			ux.Logger.PrintToUser("The upgrade bytes at %s are already up to date. No changes applied", upgradeBytesPath)
 			return nil
 		}
-		if err := app.WriteLockUpgradeFile(subnetName, jsonBytes); err != nil {
-			app.Log.Debug("failed to write upgrades lock file", zap.Error(err))
+		// the node may already be running with the previous upgrades
+		if _, err := validateUpgradeBytes(netUpgradeBytes, nodeUpgradeBytes); err != nil {
+			return fmt.Errorf("upgrade bytes at %s are not compatible with the new ones: %w", upgradeBytesPath, err)
 		}
+	}
 
-		return nil
+	if err := os.MkdirAll(filepath.Dir(upgradeBytesPath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(upgradeBytesPath, netUpgradeBytes, application.WriteReadReadPerms); err != nil {
+		return fmt.Errorf("failed to write upgrade bytes into %s: %w", upgradeBytesPath, err)
 	}
+	writeLockFile(subnetName, precmpUpgrades)
 
-	return errors.New("unexpected network size of zero nodes")
+	ux.Logger.PrintToUser("Upgrade bytes have been written to %s", upgradeBytesPath)
+	nextUpgrade, err := getEarliestTimestamp(precmpUpgrades)
+	if err == nil {
+		ux.Logger.PrintToUser("The next upgrade will go into effect %s", time.Unix(nextUpgrade, 0).Local().Format(constants.TimeParseLayout))
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser(logging.Yellow.Wrap(
+		"Your avalanchego node must be restarted for the upgrade to take effect. " +
+			"Make sure to restart all the validators of the subnet before the upgrade time."))
+	return nil
+}
+
+// it seems all went well this far, now we try to write/update the lock file
+// if this fails, we probably don't want to cause an error to the user?
+// so we are silently failing, just write a log entry
+func writeLockFile(subnetName string, precmpUpgrades []params.PrecompileUpgrade) {
+	wrapper := params.UpgradeConfig{
+		PrecompileUpgrades: precmpUpgrades,
+	}
+	jsonBytes, err := json.Marshal(wrapper)
+	if err != nil {
+		app.Log.Debug("failed to marshaling upgrades lock file content", zap.Error(err))
+		return
+	}
+	if err := app.WriteLockUpgradeFile(subnetName, jsonBytes); err != nil {
+		app.Log.Debug("failed to write upgrades lock file", zap.Error(err))
+	}
 }
 
 func subnetNotYetDeployed() error {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -77,6 +78,20 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		return nil
 	}
 
+	genesisPrecompiles, lockUpgrades, err := loadCurrentPrecompiles(subnetName)
+	if err != nil {
+		return err
+	}
+	// the new upgrades are appended to the ones already applied, so that
+	// the resulting file contains the content of the lock file
+	precompiles := params.UpgradeConfig{
+		PrecompileUpgrades: lockUpgrades,
+	}
+	statuses, err := validateUpgradeSequence(genesisPrecompiles, precompiles.PrecompileUpgrades)
+	if err != nil {
+		return fmt.Errorf("the current precompile configuration is invalid: %w", err)
+	}
+
 	allPreComps := []string{
 		vm.ContractAllowList,
 		vm.FeeManager,
@@ -84,17 +99,17 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		vm.TxAllowList,
 	}
 
+	fmt.Println()
+	ux.Logger.PrintToUser("Current precompile status (genesis and applied upgrades):")
+	for _, precomp := range allPreComps {
+		ux.Logger.PrintToUser("  %s: %s", precomp, statuses[precomp])
+	}
 	fmt.Println()
 	ux.Logger.PrintToUser(logging.Yellow.Wrap(
 		"Avalanchego and this tool support configuring multiple precompiles. " +
 			"However, we suggest to only configure one per upgrade."))
 	fmt.Println()
 
-	// use the correct data types from subnet-evm right away
-	precompiles := params.UpgradeConfig{
-		PrecompileUpgrades: make([]params.PrecompileUpgrade, 0),
-	}
-
 	for {
 		precomp, err := app.Prompt.CaptureList("Select the precompile to configure", allPreComps)
 		if err != nil {
@@ -102,7 +117,11 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 		}
 
 		ux.Logger.PrintToUser(fmt.Sprintf("Set parameters for the %q precompile", precomp))
-		if err := promptParams(precomp, &precompiles.PrecompileUpgrades); err != nil {
+		if err := promptAction(precomp, statuses[precomp], &precompiles.PrecompileUpgrades); err != nil {
+			return err
+		}
+		statuses, err = validateUpgradeSequence(genesisPrecompiles, precompiles.PrecompileUpgrades)
+		if err != nil {
 			return err
 		}
 
@@ -132,6 +151,99 @@ func upgradeGenerateCmd(_ *cobra.Command, args []string) error {
 	return app.WriteUpgradeFile(subnetName, jsonBytes)
 }
 
+// returns the precompiles enabled at genesis, and the upgrades already
+// applied to the subnet (lock file)
+func loadCurrentPrecompiles(subnetName string) (params.PrecompileUpgrade, []params.PrecompileUpgrade, error) {
+	genesis, err := app.LoadEvmGenesis(subnetName)
+	if err != nil {
+		return params.PrecompileUpgrade{}, nil, fmt.Errorf("failed loading genesis: %w", err)
+	}
+	var genesisPrecompiles params.PrecompileUpgrade
+	if genesis.Config != nil {
+		genesisPrecompiles = genesis.Config.PrecompileUpgrade
+	}
+	lockUpgrades := []params.PrecompileUpgrade{}
+	lockUpgradeBytes, err := app.ReadLockUpgradeFile(subnetName)
+	if err != nil && !os.IsNotExist(err) {
+		return params.PrecompileUpgrade{}, nil, err
+	}
+	if len(lockUpgradeBytes) > 0 {
+		lockUpgrades, err = getAllUpgrades(lockUpgradeBytes)
+		if err != nil {
+			return params.PrecompileUpgrade{}, nil, fmt.Errorf("failed loading upgrades lock file: %w", err)
+		}
+	}
+	return genesisPrecompiles, lockUpgrades, nil
+}
+
+// prompts the user for the action to do on [precomp], according to its current [status]:
+// - a precompile that is not enabled can be enabled
+// - an enabled precompile can be disabled or reconfigured (disabled and enabled again
+// with new parameters)
+func promptAction(precomp string, status precompileStatus, precompiles *[]params.PrecompileUpgrade) error {
+	const (
+		enableAction      = "Enable"
+		disableAction     = "Disable"
+		reconfigureAction = "Reconfigure"
+	)
+	action := enableAction
+	if status.enabled {
+		reconfigureOption := reconfigureAction + " (change allow lists)"
+		if precomp == vm.FeeManager {
+			reconfigureOption = reconfigureAction + " (change allow lists or fee config)"
+		}
+		options := []string{disableAction, reconfigureOption}
+		choice, err := app.Prompt.CaptureList(fmt.Sprintf("The %q precompile is %s. What do you want to do?", precomp, status), options)
+		if err != nil {
+			return err
+		}
+		switch choice {
+		case disableAction:
+			action = disableAction
+		case reconfigureOption:
+			action = reconfigureAction
+		}
+	}
+
+	date, err := queryActivationTimestamp()
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case disableAction:
+		return promptDisableParams(precomp, precompiles, date)
+	case reconfigureAction:
+		// the precompile is disabled and then enabled again with the new config
+		// on the next second, as upgrade timestamps must be strictly increasing
+		if err := promptDisableParams(precomp, precompiles, date); err != nil {
+			return err
+		}
+		return promptParams(precomp, precompiles, date.Add(time.Second))
+	default:
+		return promptParams(precomp, precompiles, date)
+	}
+}
+
+func promptDisableParams(precomp string, precompiles *[]params.PrecompileUpgrade, date time.Time) error {
+	var upgrade params.PrecompileUpgrade
+	timestamp := big.NewInt(date.Unix())
+	switch precomp {
+	case vm.ContractAllowList:
+		upgrade.ContractDeployerAllowListConfig = precompile.NewDisableContractDeployerAllowListConfig(timestamp)
+	case vm.TxAllowList:
+		upgrade.TxAllowListConfig = precompile.NewDisableTxAllowListConfig(timestamp)
+	case vm.NativeMint:
+		upgrade.ContractNativeMinterConfig = precompile.NewDisableContractNativeMinterConfig(timestamp)
+	case vm.FeeManager:
+		upgrade.FeeManagerConfig = precompile.NewDisableFeeManagerConfig(timestamp)
+	default:
+		return fmt.Errorf("unexpected precompile identifier: %q", precomp)
+	}
+	*precompiles = append(*precompiles, upgrade)
+	return nil
+}
+
 func queryActivationTimestamp() (time.Time, error) {
 	const (
 		in5min   = "In 5 minutes"
@@ -170,11 +282,7 @@ func queryActivationTimestamp() (time.Time, error) {
 	return date, nil
 }
 
-func promptParams(precomp string, precompiles *[]params.PrecompileUpgrade) error {
-	date, err := queryActivationTimestamp()
-	if err != nil {
-		return err
-	}
+func promptParams(precomp string, precompiles *[]params.PrecompileUpgrade, date time.Time) error {
 	switch precomp {
 	case vm.ContractAllowList:
 		return promptContractAllowListParams(precompiles, date)
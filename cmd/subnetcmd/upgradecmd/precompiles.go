@@ -0,0 +1,120 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile"
+)
+
+var (
+	errDisableBeforeEnable  = errors.New("precompile can't be disabled before being enabled")
+	errEnableAlreadyEnabled = errors.New("precompile is already enabled, it must be disabled first")
+	errTimestampNotIncrease = errors.New("precompile upgrade timestamps must be strictly increasing")
+)
+
+// precompileEvent is a state change of a precompile, scheduled
+// either at genesis or on the upgrade bytes
+type precompileEvent struct {
+	precompile string
+	timestamp  int64
+	disable    bool
+}
+
+// precompileStatus is the state of a precompile after a sequence of events
+type precompileStatus struct {
+	enabled bool
+	// timestamp of the last event, or 0 if enabled at genesis
+	since int64
+	// true if there has been at least one event for the precompile
+	configured bool
+}
+
+func newPrecompileEvent(precomp string, conf precompile.UpgradeableConfig) precompileEvent {
+	var ts int64
+	if conf.BlockTimestamp != nil && conf.BlockTimestamp.IsInt64() {
+		ts = conf.BlockTimestamp.Int64()
+	}
+	return precompileEvent{
+		precompile: precomp,
+		timestamp:  ts,
+		disable:    conf.Disable,
+	}
+}
+
+// returns the precompile events contained in [upgrade]
+func getPrecompileEvents(upgrade params.PrecompileUpgrade) []precompileEvent {
+	events := []precompileEvent{}
+	if upgrade.ContractDeployerAllowListConfig != nil {
+		events = append(events, newPrecompileEvent(vm.ContractAllowList, upgrade.ContractDeployerAllowListConfig.UpgradeableConfig))
+	}
+	if upgrade.FeeManagerConfig != nil {
+		events = append(events, newPrecompileEvent(vm.FeeManager, upgrade.FeeManagerConfig.UpgradeableConfig))
+	}
+	if upgrade.ContractNativeMinterConfig != nil {
+		events = append(events, newPrecompileEvent(vm.NativeMint, upgrade.ContractNativeMinterConfig.UpgradeableConfig))
+	}
+	if upgrade.TxAllowListConfig != nil {
+		events = append(events, newPrecompileEvent(vm.TxAllowList, upgrade.TxAllowListConfig.UpgradeableConfig))
+	}
+	return events
+}
+
+// validates that the sequence of [upgrades] can be applied on top of the [genesisPrecompiles]:
+// for each precompile, enables and disables must alternate, starting with an
+// enable (unless enabled at genesis), and timestamps must be strictly increasing
+// returns the status of each configured precompile after the sequence
+func validateUpgradeSequence(
+	genesisPrecompiles params.PrecompileUpgrade,
+	upgrades []params.PrecompileUpgrade,
+) (map[string]precompileStatus, error) {
+	statuses := map[string]precompileStatus{}
+	for _, event := range getPrecompileEvents(genesisPrecompiles) {
+		statuses[event.precompile] = precompileStatus{
+			enabled:    !event.disable,
+			since:      event.timestamp,
+			configured: true,
+		}
+	}
+	for i, upgrade := range upgrades {
+		for _, event := range getPrecompileEvents(upgrade) {
+			status := statuses[event.precompile]
+			if status.configured && event.timestamp <= status.since {
+				return nil, fmt.Errorf("%w: upgrade %d for %q at %d, previous at %d",
+					errTimestampNotIncrease, i, event.precompile, event.timestamp, status.since)
+			}
+			if event.disable && !status.enabled {
+				return nil, fmt.Errorf("%w: upgrade %d for %q", errDisableBeforeEnable, i, event.precompile)
+			}
+			if !event.disable && status.enabled {
+				return nil, fmt.Errorf("%w: upgrade %d for %q", errEnableAlreadyEnabled, i, event.precompile)
+			}
+			statuses[event.precompile] = precompileStatus{
+				enabled:    !event.disable,
+				since:      event.timestamp,
+				configured: true,
+			}
+		}
+	}
+	return statuses, nil
+}
+
+// human readable description of a precompile status
+func (s precompileStatus) String() string {
+	switch {
+	case !s.configured:
+		return "never enabled"
+	case s.enabled && s.since == 0:
+		return "enabled at genesis"
+	case s.enabled:
+		return "enabled at " + time.Unix(s.since, 0).Local().Format(constants.TimeParseLayout)
+	default:
+		return "disabled at " + time.Unix(s.since, 0).Local().Format(constants.TimeParseLayout)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/precompile"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func enableTxAllowList(ts int64) params.PrecompileUpgrade {
+	admins := []common.Address{common.HexToAddress("0xb794F5eA0ba39494cE839613fffBA74279579268")}
+	return params.PrecompileUpgrade{
+		TxAllowListConfig: precompile.NewTxAllowListConfig(big.NewInt(ts), admins, nil),
+	}
+}
+
+func disableTxAllowList(ts int64) params.PrecompileUpgrade {
+	return params.PrecompileUpgrade{
+		TxAllowListConfig: precompile.NewDisableTxAllowListConfig(big.NewInt(ts)),
+	}
+}
+
+func TestValidateUpgradeSequence(t *testing.T) {
+	type testRun struct {
+		name        string
+		genesis     params.PrecompileUpgrade
+		upgrades    []params.PrecompileUpgrade
+		enabled     bool
+		expectedErr error
+	}
+
+	tests := []testRun{
+		{
+			name:     "enable",
+			upgrades: []params.PrecompileUpgrade{enableTxAllowList(100)},
+			enabled:  true,
+		},
+		{
+			name:     "enable, disable and re-enable",
+			upgrades: []params.PrecompileUpgrade{enableTxAllowList(100), disableTxAllowList(200), enableTxAllowList(201)},
+			enabled:  true,
+		},
+		{
+			name:     "disable genesis precompile",
+			genesis:  enableTxAllowList(0),
+			upgrades: []params.PrecompileUpgrade{disableTxAllowList(100)},
+			enabled:  false,
+		},
+		{
+			name:        "disable before enable",
+			upgrades:    []params.PrecompileUpgrade{disableTxAllowList(100)},
+			expectedErr: errDisableBeforeEnable,
+		},
+		{
+			name:        "enable twice",
+			upgrades:    []params.PrecompileUpgrade{enableTxAllowList(100), enableTxAllowList(200)},
+			expectedErr: errEnableAlreadyEnabled,
+		},
+		{
+			name:        "enable genesis precompile",
+			genesis:     enableTxAllowList(0),
+			upgrades:    []params.PrecompileUpgrade{enableTxAllowList(100)},
+			expectedErr: errEnableAlreadyEnabled,
+		},
+		{
+			name:        "same timestamp",
+			upgrades:    []params.PrecompileUpgrade{enableTxAllowList(100), disableTxAllowList(100)},
+			expectedErr: errTimestampNotIncrease,
+		},
+		{
+			name:        "decreasing timestamp",
+			upgrades:    []params.PrecompileUpgrade{enableTxAllowList(100), disableTxAllowList(50)},
+			expectedErr: errTimestampNotIncrease,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			statuses, err := validateUpgradeSequence(tt.genesis, tt.upgrades)
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.enabled, statuses[vm.TxAllowList].enabled)
+			require.False(statuses[vm.FeeManager].configured)
+		})
+	}
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/params"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+const (
+	scheduledState  = "scheduled"
+	notAppliedState = "not applied to nodes"
+	activatedState  = "activated"
+	supersededState = "superseded"
+	divergedState   = "diverged"
+	unknownState    = "unknown"
+
+	genesisSource = "genesis"
+)
+
+var rpcEndpoint string
+
+// avalanche subnet upgrade status
+func newUpgradeStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [subnetName]",
+		Short: "Show the timeline of the subnet upgrades and their activation status",
+		Long: `Show a chronological timeline of the precompile configuration of a subnet, merging the
+genesis, the upgrade bytes file and the upgrades already applied to nodes (lock file).
+The deployed chain is queried to mark each upgrade as scheduled, activated or diverged.`,
+		RunE:         upgradeStatusCmd,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&useLocal, "local", false, "show upgrade status of `local` deployment")
+	cmd.Flags().BoolVar(&useFuji, "fuji", false, "show upgrade status of `fuji` deployment (alias for `testnet`)")
+	cmd.Flags().BoolVar(&useFuji, "testnet", false, "show upgrade status of `testnet` deployment (alias for `fuji`)")
+	cmd.Flags().BoolVar(&useMainnet, "mainnet", false, "show upgrade status of `mainnet` deployment")
+	cmd.Flags().StringVar(&rpcEndpoint, "endpoint", "", "base URL of the node API to query (defaults to the network API endpoint)")
+
+	return cmd
+}
+
+// timelineEntry is a precompile event of the timeline, together with the
+// files it was found at and its activation state
+type timelineEntry struct {
+	precompileEvent
+	sources []string
+	locked  bool
+	state   string
+}
+
+func upgradeStatusCmd(_ *cobra.Command, args []string) error {
+	subnetName := args[0]
+	if !app.GenesisExists(subnetName) {
+		ux.Logger.PrintToUser("The provided subnet name %q does not exist", subnetName)
+		return nil
+	}
+	if !atMostOneNetworkSelected() {
+		return errors.New("too many networks selected")
+	}
+
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("unable to load sidecar: %w", err)
+	}
+	if sc.VM != models.SubnetEvm {
+		return errors.New("upgrade status is only supported for Subnet-EVM subnets")
+	}
+
+	networkToUpgrade, err := selectNetworkToUpgrade(sc, nil)
+	if err != nil {
+		return err
+	}
+	var network models.Network
+	switch networkToUpgrade {
+	case localDeployment:
+		network = models.Local
+	case fujiDeployment:
+		network = models.Fuji
+	case mainnetDeployment:
+		network = models.Mainnet
+	default:
+		return errors.New("unsupported network")
+	}
+	blockchainID := sc.Networks[app.GetNetworkKey(network)].BlockchainID
+	if blockchainID == ids.Empty {
+		return subnetNotYetDeployed()
+	}
+
+	entries, err := buildTimeline(subnetName)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		ux.Logger.PrintToUser("No precompiles configured for subnet %s", subnetName)
+		return nil
+	}
+
+	baseURL := rpcEndpoint
+	if baseURL == "" {
+		baseURL, err = app.GetAPIEndpoint(network)
+		if err != nil {
+			return err
+		}
+	}
+	rpcURL := fmt.Sprintf("%s/ext/bc/%s/rpc", baseURL, blockchainID)
+	chainTime, activePrecompiles, err := getChainPrecompiles(rpcURL)
+	if err != nil {
+		app.Log.Debug("failed querying chain state", zap.String("url", rpcURL), zap.Error(err))
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Unable to query the chain at %s, activation status can't be verified", rpcURL)))
+		chainTime = 0
+	}
+	setTimelineStates(entries, chainTime, activePrecompiles)
+
+	if chainTime != 0 {
+		ux.Logger.PrintToUser("Latest block timestamp: %s", time.Unix(chainTime, 0).Local().Format(constants.TimeParseLayout))
+	}
+	printTimeline(entries)
+	return nil
+}
+
+// merges genesis precompiles, upgrade bytes and lock file into a chronological
+// list of precompile events
+func buildTimeline(subnetName string) ([]*timelineEntry, error) {
+	genesisPrecompiles, lockUpgrades, err := loadCurrentPrecompiles(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	var upgrades []params.PrecompileUpgrade
+	upgradeBytes, err := app.ReadUpgradeFile(subnetName)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(upgradeBytes) > 0 {
+		upgrades, err = getAllUpgrades(upgradeBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed loading upgrade bytes file: %w", err)
+		}
+	}
+
+	entries := []*timelineEntry{}
+	for _, event := range getPrecompileEvents(genesisPrecompiles) {
+		entries = append(entries, &timelineEntry{
+			precompileEvent: event,
+			sources:         []string{genesisSource},
+			locked:          true,
+		})
+	}
+	addUpgrades := func(upgrades []params.PrecompileUpgrade, source string, locked bool) {
+		for _, upgrade := range upgrades {
+			for _, event := range getPrecompileEvents(upgrade) {
+				found := false
+				for _, entry := range entries {
+					if entry.precompileEvent == event {
+						entry.sources = append(entry.sources, source)
+						entry.locked = entry.locked || locked
+						found = true
+						break
+					}
+				}
+				if !found {
+					entries = append(entries, &timelineEntry{
+						precompileEvent: event,
+						sources:         []string{source},
+						locked:          locked,
+					})
+				}
+			}
+		}
+	}
+	addUpgrades(upgrades, constants.UpgradeBytesFileName, false)
+	addUpgrades(lockUpgrades, constants.UpgradeBytesFileName+constants.UpgradeBytesLockExtension, true)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].timestamp < entries[j].timestamp
+	})
+	return entries, nil
+}
+
+// sets the state of each timeline entry, given the [chainTime] of the latest block
+// and the precompiles [activePrecompiles] at that time.
+// if [chainTime] is 0, the chain state is unknown
+//   - entries after [chainTime] are scheduled, or not applied if they are not on the lock file
+//   - for each precompile, the last entry before [chainTime] is activated if the chain
+//     state matches it, or diverged if not
+//   - previous entries are superseded
+func setTimelineStates(entries []*timelineEntry, chainTime int64, activePrecompiles map[string]bool) {
+	lastPastEntry := map[string]*timelineEntry{}
+	for _, entry := range entries {
+		switch {
+		case chainTime == 0 && !entry.locked:
+			entry.state = notAppliedState
+		case chainTime == 0:
+			entry.state = unknownState
+		case entry.timestamp > chainTime && !entry.locked:
+			entry.state = notAppliedState
+		case entry.timestamp > chainTime:
+			entry.state = scheduledState
+		default:
+			if prev, ok := lastPastEntry[entry.precompile]; ok {
+				prev.state = supersededState
+			}
+			lastPastEntry[entry.precompile] = entry
+			entry.state = activatedState
+		}
+	}
+	if chainTime == 0 {
+		return
+	}
+	for precomp, entry := range lastPastEntry {
+		if activePrecompiles[precomp] == entry.disable {
+			entry.state = divergedState
+		}
+	}
+}
+
+// queries the chain at [rpcURL] for the latest block timestamp and the
+// precompiles that are active at it
+func getChainPrecompiles(rpcURL string) (int64, map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	rpcClient, err := rpc.DialContext(ctx, rpcURL)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rpcClient.Close()
+	client := ethclient.NewClient(rpcClient)
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	chainTime := int64(header.Time)
+	var activeUpgrade params.PrecompileUpgrade
+	if err := rpcClient.CallContext(ctx, &activeUpgrade, "eth_getActivePrecompilesAt", big.NewInt(chainTime)); err != nil {
+		return 0, nil, err
+	}
+	activePrecompiles := map[string]bool{}
+	for _, event := range getPrecompileEvents(activeUpgrade) {
+		activePrecompiles[event.precompile] = !event.disable
+	}
+	return chainTime, activePrecompiles, nil
+}
+
+func printTimeline(entries []*timelineEntry) {
+	table := tablewriter.NewWriter(os.Stdout)
+	header := []string{"Timestamp", "Precompile", "Action", "Source", "Status"}
+	table.SetHeader(header)
+	table.SetRowLine(true)
+	for _, entry := range entries {
+		timestamp := genesisSource
+		if entry.timestamp != 0 {
+			timestamp = time.Unix(entry.timestamp, 0).Local().Format(constants.TimeParseLayout)
+		}
+		action := "enable"
+		if entry.disable {
+			action = "disable"
+		}
+		state := entry.state
+		switch state {
+		case divergedState, notAppliedState:
+			state = logging.Red.Wrap(state)
+		case activatedState:
+			state = logging.Green.Wrap(state)
+		}
+		table.Append([]string{timestamp, entry.precompile, action, strings.Join(entry.sources, ", "), state})
+	}
+	table.Render()
+}
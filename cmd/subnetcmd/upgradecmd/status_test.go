@@ -0,0 +1,46 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package upgradecmd
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTimelineStates(t *testing.T) {
+	require := require.New(t)
+
+	newEntries := func() []*timelineEntry {
+		return []*timelineEntry{
+			{precompileEvent: precompileEvent{precompile: vm.TxAllowList, timestamp: 0}, locked: true},
+			{precompileEvent: precompileEvent{precompile: vm.TxAllowList, timestamp: 100, disable: true}, locked: true},
+			{precompileEvent: precompileEvent{precompile: vm.FeeManager, timestamp: 150}, locked: true},
+			{precompileEvent: precompileEvent{precompile: vm.FeeManager, timestamp: 300, disable: true}, locked: true},
+			{precompileEvent: precompileEvent{precompile: vm.NativeMint, timestamp: 400}},
+		}
+	}
+
+	// chain state matches the timeline
+	entries := newEntries()
+	setTimelineStates(entries, 200, map[string]bool{vm.FeeManager: true})
+	require.Equal(supersededState, entries[0].state)
+	require.Equal(activatedState, entries[1].state)
+	require.Equal(activatedState, entries[2].state)
+	require.Equal(scheduledState, entries[3].state)
+	require.Equal(notAppliedState, entries[4].state)
+
+	// fee manager was never activated on chain
+	entries = newEntries()
+	setTimelineStates(entries, 200, map[string]bool{})
+	require.Equal(activatedState, entries[1].state)
+	require.Equal(divergedState, entries[2].state)
+
+	// chain state unknown
+	entries = newEntries()
+	setTimelineStates(entries, 0, nil)
+	require.Equal(unknownState, entries[0].state)
+	require.Equal(unknownState, entries[3].state)
+	require.Equal(notAppliedState, entries[4].state)
+}
@@ -38,5 +38,7 @@ updating your developmental and deployed subnets.`,
 	cmd.AddCommand(newUpgradePrintCmd())
 	// subnet upgrade apply
 	cmd.AddCommand(newUpgradeApplyCmd())
+	// subnet upgrade status
+	cmd.AddCommand(newUpgradeStatusCmd())
 	return cmd
 }
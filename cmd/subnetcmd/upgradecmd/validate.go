@@ -31,39 +31,51 @@ func validateUpgradeBytes(file []byte, lockFile []byte) ([]params.PrecompileUpgr
 		return nil, err
 	}
 
+	var lockUpgrades []params.PrecompileUpgrade
 	if len(lockFile) > 0 {
-		lockUpgrades, err := getAllUpgrades(lockFile)
+		lockUpgrades, err = getAllUpgrades(lockFile)
 		if err != nil {
 			return nil, err
 		}
-		match := 0
 		for _, lu := range lockUpgrades {
-			for _, u := range upgrades {
-				if reflect.DeepEqual(u, lu) {
-					match++
-					break
-				}
+			if !containsUpgrade(upgrades, lu) {
+				return nil, errNewUpgradesNotContainsLock
 			}
 		}
-		if match != len(lockUpgrades) {
-			return nil, errNewUpgradesNotContainsLock
-		}
 	}
 
-	allTimestamps, err := getAllTimestamps(upgrades)
-	if err != nil {
+	if _, err := getAllTimestamps(upgrades); err != nil {
 		return nil, err
 	}
 
-	for _, ts := range allTimestamps {
-		if time.Unix(ts, 0).Before(time.Now()) {
-			return nil, errBlockTimestampInthePast
+	for _, u := range upgrades {
+		// upgrades already applied (in the lock file) may be in the past
+		if containsUpgrade(lockUpgrades, u) {
+			continue
+		}
+		timestamps, err := getAllTimestamps([]params.PrecompileUpgrade{u})
+		if err != nil {
+			return nil, err
+		}
+		for _, ts := range timestamps {
+			if time.Unix(ts, 0).Before(time.Now()) {
+				return nil, errBlockTimestampInthePast
+			}
 		}
 	}
 
 	return upgrades, nil
 }
 
+func containsUpgrade(upgrades []params.PrecompileUpgrade, upgrade params.PrecompileUpgrade) bool {
+	for _, u := range upgrades {
+		if reflect.DeepEqual(u, upgrade) {
+			return true
+		}
+	}
+	return false
+}
+
 func getAllTimestamps(upgrades []params.PrecompileUpgrade) ([]int64, error) {
 	var allTimestamps []int64
 
@@ -255,3 +255,19 @@ func TestLockFile(t *testing.T) {
 		})
 	}
 }
+
+func TestLockedUpgradesInThePast(t *testing.T) {
+	require := require.New(t)
+
+	pastUpgrade := `{"feeManagerConfig":{"adminAddresses":["0xb794F5eA0ba39494cE839613fffBA74279579268"],"blockTimestamp":1674496268,"initialFeeConfig":{}}}`
+	futureUpgrade := fmt.Sprintf(`{"txAllowListConfig":{"adminAddresses":["0xb794F5eA0ba39494cE839613fffBA74279579268"],"blockTimestamp":%d}}`,
+		time.Now().Add(1*time.Minute).Unix())
+	lockFile := []byte(fmt.Sprintf(`{"precompileUpgrades":[%s]}`, pastUpgrade))
+
+	upgrades, err := validateUpgradeBytes([]byte(fmt.Sprintf(`{"precompileUpgrades":[%s,%s]}`, pastUpgrade, futureUpgrade)), lockFile)
+	require.NoError(err)
+	require.Len(upgrades, 2)
+
+	_, err = validateUpgradeBytes([]byte(fmt.Sprintf(`{"precompileUpgrades":[%s]}`, pastUpgrade)), nil)
+	require.ErrorIs(err, errBlockTimestampInthePast)
+}
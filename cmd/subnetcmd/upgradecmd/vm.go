@@ -3,28 +3,40 @@
 package upgradecmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/localnetworkinterface"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/plugins"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-cli/pkg/vm"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 )
 
 const (
 	futureDeployment  = "Update config for future deployments"
 	localDeployment   = "Existing local deployment"
 	fujiDeployment    = "Fuji"
-	mainnetDeployment = "Mainnet (coming soon)"
+	mainnetDeployment = "Mainnet"
 )
 
 var (
-	pluginDir string
+	errIncompatibleRPCVersion = errors.New("incompatible RPC protocol version")
+
+	pluginDir    string
+	nodeEndpoint string
+	useRollback  bool
 
 	useFuji       bool
 	useMainnet    bool
@@ -55,6 +67,9 @@ func newUpgradeVMCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&useManual, "print", false, "print instructions for upgrading")
 	cmd.Flags().StringVar(&pluginDir, "plugin-dir", "", "plugin directory to automatically upgrade VM")
+	cmd.Flags().StringVar(&nodeEndpoint, "node-endpoint", "",
+		"API endpoint of the node to check the VM compatibility against (fuji and mainnet only, not checked if not given)")
+	cmd.Flags().BoolVar(&useRollback, "rollback", false, "restore the VM binary replaced on the last upgrade (fuji and mainnet only)")
 
 	cmd.Flags().BoolVar(&useLatest, "latest", false, "upgrade to latest version")
 	cmd.Flags().StringVar(&targetVersion, "version", "", "Upgrade to custom version")
@@ -90,6 +105,10 @@ func upgradeVM(_ *cobra.Command, args []string) error {
 		return errors.New("--print and --plugin-dir are mutually exclusive")
 	}
 
+	if useRollback && (useLatest || targetVersion != "" || binaryPathArg != "" || useManual) {
+		return errors.New("--rollback can't be combined with a version or --print")
+	}
+
 	subnetName := args[0]
 
 	if !app.SubnetConfigExists(subnetName) {
@@ -102,11 +121,30 @@ func upgradeVM(_ *cobra.Command, args []string) error {
 	}
 
 	upgradeOptions := []string{futureDeployment}
+	if useRollback {
+		upgradeOptions = nil
+	}
 	networkToUpgrade, err := selectNetworkToUpgrade(sc, upgradeOptions)
 	if err != nil {
 		return err
 	}
 
+	release, err := lockForUpgrade(subnetName, networkToUpgrade)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// reload the sidecar, as it may have changed while waiting for the locks
+	sc, err = app.LoadSidecar(subnetName)
+	if err != nil {
+		return fmt.Errorf("unable to load sidecar: %w", err)
+	}
+
+	if useRollback {
+		return rollbackVM(sc, networkToUpgrade)
+	}
+
 	vmType := sc.VM
 	if vmType == models.SubnetEvm || vmType == models.SpacesVM {
 		return selectUpdateOption(subnetName, vmType, sc, networkToUpgrade)
@@ -116,6 +154,19 @@ func upgradeVM(_ *cobra.Command, args []string) error {
 	return updateToCustomBin(subnetName, sc, networkToUpgrade, binaryPathArg)
 }
 
+// locks [subnetName], after the target local network when upgrading its
+// deployment there, as the local network is always locked first
+func lockForUpgrade(subnetName string, networkToUpgrade string) (func(), error) {
+	if networkToUpgrade == localDeployment {
+		return app.LockLocalNetworkAndSubnet(subnetName)
+	}
+	lock, err := app.LockSubnet(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	return lock.Release, nil
+}
+
 // select which network to upgrade
 // optionally provide a list of options to preload
 func selectNetworkToUpgrade(sc models.Sidecar, upgradeOptions []string) (string, error) {
@@ -136,7 +187,7 @@ func selectNetworkToUpgrade(sc models.Sidecar, upgradeOptions []string) (string,
 	}
 
 	// check if subnet already deployed locally
-	locallyDeployedSubnets, err := subnet.GetLocallyDeployedSubnets()
+	locallyDeployedSubnets, err := subnet.GetLocallyDeployedSubnets(app)
 	if err != nil {
 		// ignore error if we can't reach the server, assume subnet isn't deployed
 		app.Log.Warn("Unable to reach server to get deployed subnets")
@@ -213,8 +264,8 @@ func updateToLatestVersion(vmType models.VMType, sc models.Sidecar, networkToUpg
 		return err
 	}
 
-	// check if current version equals latest
-	if currentVersion == "latest" || currentVersion == latestVersion {
+	// check if current version equals latest. A locally built VM is always replaced
+	if sc.VMPath == "" && (currentVersion == "latest" || currentVersion == latestVersion) {
 		ux.Logger.PrintToUser("VM already up-to-date")
 		return nil
 	}
@@ -235,8 +286,8 @@ func updateToSpecificVersion(sc models.Sidecar, networkToUpgrade string) error {
 		}
 	}
 
-	// check if current version equals chosen version
-	if currentVersion == targetVersion {
+	// check if current version equals chosen version. A locally built VM is always replaced
+	if sc.VMPath == "" && currentVersion == targetVersion {
 		ux.Logger.PrintToUser("VM already up-to-date")
 		return nil
 	}
@@ -251,9 +302,9 @@ func updateVMByNetwork(sc models.Sidecar, targetVersion string, networkToUpgrade
 	case localDeployment:
 		return updateExistingLocalVM(sc, targetVersion)
 	case fujiDeployment:
-		return chooseManualOrAutomatic(sc, targetVersion, networkToUpgrade)
+		return chooseManualOrAutomatic(sc, targetVersion, models.Fuji)
 	case mainnetDeployment:
-		return updateMainnetVM()
+		return chooseManualOrAutomatic(sc, targetVersion, models.Mainnet)
 	default:
 		return errors.New("unknown deployment")
 	}
@@ -273,6 +324,10 @@ func updateToCustomBin(subnetName string, sc models.Sidecar, networkToUpgrade, b
 func updateFutureVM(sc models.Sidecar, targetVersion string) error {
 	// to switch to new version, just need to update sidecar
 	sc.VMVersion = targetVersion
+	if sc.VMPath != "" {
+		ux.Logger.PrintToUser("Local deploys will no longer use the VM binary at %s", sc.VMPath)
+		sc.VMPath = ""
+	}
 	if err := app.UpdateSidecar(&sc); err != nil {
 		return err
 	}
@@ -280,17 +335,167 @@ func updateFutureVM(sc models.Sidecar, targetVersion string) error {
 	return nil
 }
 
-func updateExistingLocalVM(_ models.Sidecar, _ string) error {
-	ux.Logger.PrintToUser("Coming soon. For now, please upgrade your existing deployments and redeploy the subnet.")
+// updateExistingLocalVM hot swaps the VM binary of a subnet deployed on the local network:
+// * checks the RPC protocol version of the target VM against the running avalanchego
+// * saves a temporary snapshot, which stops the network
+// * installs the new binary into the plugin dir under the subnet's VMID
+// * loads the snapshot back, so the nodes restart with the new binary and keep the chain state
+func updateExistingLocalVM(sc models.Sidecar, targetVersion string) error {
+	networkKey := app.GetNetworkKey(models.Local)
+	if sc.Networks[networkKey] == (models.NetworkData{}) {
+		return subnetNotYetDeployed()
+	}
+
+	vmBin, err := getVMBinary(sc, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	// custom binaries have no compatibility info, so we rely on the user here
+	var rpcVersion int
+	if sc.VM != models.CustomVM {
+		rpcVersion, err = vm.GetRPCProtocolVersion(app, sc.VM, targetVersion)
+		if err != nil {
+			return fmt.Errorf("failed getting RPC protocol version for %s %s: %w", sc.VM, targetVersion, err)
+		}
+		avagoVersion, avagoRPCVersion, running, err := localnetworkinterface.NewStatusChecker(app.GetLocalAPIEndpoint()).GetCurrentNetworkVersion()
+		if err != nil {
+			return err
+		}
+		if !running {
+			ux.Logger.PrintToUser(ErrNetworkNotStartedOutput)
+			return errors.New("local network not running")
+		}
+		if rpcVersion != avagoRPCVersion {
+			return fmt.Errorf("%w: %s %s uses RPC protocol version %d, but the local network runs avalanchego %s with version %d",
+				errIncompatibleRPCVersion, sc.VM, targetVersion, rpcVersion, avagoVersion, avagoRPCVersion)
+		}
+	}
+
+	vmid, err := sc.GetVMID()
+	if err != nil {
+		return err
+	}
+
+	if sc.VMPath != "" {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"The local network runs the VM binary built at %s, which will be replaced", sc.VMPath)))
+	}
+
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		ux.Logger.PrintToUser(ErrNetworkNotStartedOutput)
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	status, err := cli.Status(ctx)
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			ux.Logger.PrintToUser(ErrNetworkNotStartedOutput)
+		}
+		return err
+	}
+	deployed := false
+	for _, s := range status.ClusterInfo.GetSubnets() {
+		if s == sc.Networks[networkKey].SubnetID.String() {
+			deployed = true
+			break
+		}
+	}
+	if !deployed {
+		return subnetNotYetDeployed()
+	}
+
+	// prepared before stopping the network, so that it's not left stopped on failure
+	loadSnapshotOpts, err := subnet.GetReloadSnapshotOpts(app)
+	if err != nil {
+		return err
+	}
+
+	// save a temporary snapshot, this stops the network so the binary can be replaced
+	snapName := sc.Name + constants.TmpSnapshotInfix + time.Now().Format(timestampFormat)
+	app.Log.Debug("saving temporary snapshot for vm upgrade", zap.String("snapshot-name", snapName))
+	if err := subnet.SaveSnapshot(ctx, app, cli, snapName); err != nil {
+		return err
+	}
+
+	installErr := replaceLocalVM(vmid, vmBin)
+	if installErr != nil {
+		ux.Logger.PrintToUser(logging.Red.Wrap("Failed installing the new VM binary, restarting the network as it was"))
+	}
+
+	// restart the network from the snapshot, with the new binary if the install succeeded
+	if _, err := cli.LoadSnapshot(ctx, snapName, loadSnapshotOpts...); err != nil {
+		return err
+	}
+	clusterInfo, err := subnet.WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	if err := subnet.UpdateLocalAPIEndpoint(app, clusterInfo); err != nil {
+		app.Log.Warn("failed recording local network API endpoint", zap.Error(err))
+	}
+	subnet.UpdateMetricsConfig(app, clusterInfo)
+	if installErr != nil {
+		return installErr
+	}
+
+	sc.VMVersion = targetVersion
+	sc.VMPath = ""
+	if sc.VM != models.CustomVM {
+		sc.RPCVersion = rpcVersion
+	}
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if sc.VM == models.CustomVM {
+		ux.Logger.PrintToUser("Network restarted with the custom VM binary")
+	} else {
+		ux.Logger.PrintToUser("Network restarted with %s %s", sc.VM, targetVersion)
+	}
+	if subnet.HasEndpoints(clusterInfo) {
+		ux.PrintTableEndpoints(clusterInfo)
+	}
 	return nil
 }
 
-func chooseManualOrAutomatic(sc models.Sidecar, targetVersion string, _ string) error {
+// returns the path of the VM binary for [targetVersion], downloading it if needed
+func getVMBinary(sc models.Sidecar, targetVersion string) (string, error) {
+	switch sc.VM {
+	case models.SubnetEvm:
+		vmBin, err := binutils.SetupSubnetEVM(app, targetVersion)
+		if err != nil {
+			return "", fmt.Errorf("failed to install subnet-evm: %w", err)
+		}
+		return vmBin, nil
+	case models.SpacesVM:
+		vmBin, err := binutils.SetupSpacesVM(app, targetVersion)
+		if err != nil {
+			return "", fmt.Errorf("failed to install spaces-vm: %w", err)
+		}
+		return vmBin, nil
+	case models.CustomVM:
+		return binutils.SetupCustomBin(app, sc.Name), nil
+	default:
+		return "", fmt.Errorf("unknown vm: %s", sc.VM)
+	}
+}
+
+// replaces the binary installed in the plugin dir for [vmid] with [vmBin]
+func replaceLocalVM(vmid, vmBin string) error {
+	return plugins.ReplaceBinary(vmBin, filepath.Join(app.GetPluginsDir(), vmid))
+}
+
+func chooseManualOrAutomatic(sc models.Sidecar, targetVersion string, network models.Network) error {
 	switch {
 	case useManual:
 		return plugins.ManualUpgrade(app, sc, targetVersion)
 	case pluginDir != "":
-		return plugins.AutomatedUpgrade(app, sc, targetVersion, pluginDir)
+		return updatePublicVM(sc, targetVersion, network)
 	}
 
 	const (
@@ -308,10 +513,70 @@ func chooseManualOrAutomatic(sc models.Sidecar, targetVersion string, _ string)
 	if choice == choiceManual {
 		return plugins.ManualUpgrade(app, sc, targetVersion)
 	}
-	return plugins.AutomatedUpgrade(app, sc, targetVersion, pluginDir)
+	return updatePublicVM(sc, targetVersion, network)
+}
+
+// updatePublicVM checks that the target VM is compatible with the avalanchego version
+// of the node, and installs it into the node plugin dir, backing up the previous binary
+func updatePublicVM(sc models.Sidecar, targetVersion string, network models.Network) error {
+	if sc.VM != models.CustomVM {
+		if err := checkNodeRPCCompatibility(sc.VM, targetVersion); err != nil {
+			return err
+		}
+	}
+	return plugins.AutomatedUpgrade(app, sc, network, targetVersion, pluginDir)
 }
 
-func updateMainnetVM() error {
-	ux.Logger.PrintToUser("Coming soon. For now, please upgrade your mainnet deployments manually.")
+// checks the RPC protocol version of [targetVersion] against the one of the node
+// at [nodeEndpoint], as given by info.getNodeVersion. The check is skipped if no
+// endpoint was given, as there is no default node to query for public networks
+func checkNodeRPCCompatibility(vmType models.VMType, targetVersion string) error {
+	if nodeEndpoint == "" {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(
+			"No node endpoint given, RPC protocol compatibility won't be verified. Use --node-endpoint to check it"))
+		return nil
+	}
+	rpcVersion, err := vm.GetRPCProtocolVersion(app, vmType, targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed getting RPC protocol version for %s %s: %w", vmType, targetVersion, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), constants.RequestTimeout)
+	defer cancel()
+	nodeVersion, err := info.NewClient(nodeEndpoint).GetNodeVersion(ctx)
+	if err != nil {
+		app.Log.Debug("failed querying node version", zap.String("endpoint", nodeEndpoint), zap.Error(err))
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Unable to query the node at %s, RPC protocol compatibility can't be verified", nodeEndpoint)))
+		yes, err := app.Prompt.CaptureYesNo("Continue with the upgrade anyway?")
+		if err != nil {
+			return err
+		}
+		if !yes {
+			return errors.New("upgrade aborted")
+		}
+		return nil
+	}
+
+	if int(nodeVersion.RPCProtocolVersion) != rpcVersion {
+		return fmt.Errorf("%w: %s %s uses RPC protocol version %d, but the node runs %s with version %d",
+			errIncompatibleRPCVersion, vmType, targetVersion, rpcVersion, nodeVersion.Version, nodeVersion.RPCProtocolVersion)
+	}
+	ux.Logger.PrintToUser("%s %s is compatible with the node version %s (RPC protocol version %d)",
+		vmType, targetVersion, nodeVersion.Version, rpcVersion)
 	return nil
 }
+
+// rollbackVM restores the VM binary replaced on the last upgrade on fuji or mainnet
+func rollbackVM(sc models.Sidecar, networkToUpgrade string) error {
+	var network models.Network
+	switch networkToUpgrade {
+	case fujiDeployment:
+		network = models.Fuji
+	case mainnetDeployment:
+		network = models.Mainnet
+	default:
+		return errors.New("rollback is only supported for fuji and mainnet deployments")
+	}
+	return plugins.RollbackUpgrade(app, sc, network, pluginDir)
+}
@@ -29,5 +29,7 @@ func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
 	cmd.AddCommand(newTransactionSignCmd())
 	// subnet upgrade generate
 	cmd.AddCommand(newTransactionCommitCmd())
+	// transaction merge
+	cmd.AddCommand(newTransactionMergeCmd())
 	return cmd
 }
@@ -3,7 +3,11 @@
 package transactioncmd
 
 import (
+	"errors"
+	"time"
+
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanche-cli/pkg/txutils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -12,18 +16,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var commitTimeout time.Duration
+
 // avalanche transaction commit
 func newTransactionCommitCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "commit [subnetName]",
-		Short:        "commit a transaction",
-		Long:         "The transaction commit command commits a transaction by submitting it to the P-Chain.",
+		Use:   "commit [subnetName]",
+		Short: "commit a transaction",
+		Long: `The transaction commit command commits a transaction by submitting it to the P-Chain,
+and waits for the P-Chain to accept it. Local subnet configuration is then updated
+according to the transaction type.`,
 		RunE:         commitTx,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 	}
 
 	cmd.Flags().StringVar(&inputTxPath, inputTxPathFlag, "", "Path to the transaction signed by all signatories")
+	cmd.Flags().DurationVar(&commitTimeout, "timeout", constants.TxCommitTimeout, "max time to wait for the transaction to be committed")
 	return cmd
 }
 
@@ -39,6 +48,9 @@ func commitTx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if !txutils.IsSubnetAuthTx(tx) {
+		return errNotSubnetAuthTx
+	}
 
 	network, err := txutils.GetNetwork(tx)
 	if err != nil {
@@ -50,17 +62,28 @@ func commitTx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	subnetID := sc.Networks[network.String()].SubnetID
+	subnetID := sc.Networks[app.GetNetworkKey(network)].SubnetID
 	if subnetID == ids.Empty {
 		return errNoSubnetID
 	}
+	if err := checkTxSubnetID(tx, subnetID); err != nil {
+		return err
+	}
+
+	rebuilt, err := checkStaleTx(tx, network, subnetName, subnetID)
+	if err != nil {
+		return err
+	}
+	if rebuilt {
+		return nil
+	}
 
-	subnetAuthKeys, err := txutils.GetAuthSigners(tx, network, subnetID)
+	subnetAuthKeys, err := txutils.GetAuthSigners(app, tx, network, subnetID)
 	if err != nil {
 		return err
 	}
 
-	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, network, subnetID)
+	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(app, tx, network, subnetID)
 	if err != nil {
 		return err
 	}
@@ -80,18 +103,15 @@ func commitTx(_ *cobra.Command, args []string) error {
 	}
 
 	deployer := subnet.NewPublicDeployer(app, false, kc, network)
-	txID, err := deployer.Commit(tx)
+	txID, err := deployer.Commit(tx, commitTimeout)
 	if err != nil {
-		return err
-	}
-
-	if txutils.IsCreateChainTx(tx) {
-		if err := subnetcmd.PrintDeployResults(subnetName, subnetID, txID, true); err != nil {
-			return err
+		if errors.Is(err, subnet.ErrTxCommitTimeout) {
+			ux.Logger.PrintToUser("Transaction %s was issued but its final status is not known yet. "+
+				"Please check it on the P-Chain before retrying.", txID)
 		}
-		return app.UpdateSidecarNetworks(&sc, network, subnetID, txID)
+		return err
 	}
-	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
 
-	return nil
+	postCommit := getPostCommitHook(tx)
+	return postCommit(tx, txID, subnetName, &sc, network, subnetID)
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// postCommitHook is executed after a subnet auth tx has been successfully
+// issued to the P-Chain, to do the tx type specific local bookkeeping
+type postCommitHook func(
+	tx *txs.Tx,
+	txID ids.ID,
+	subnetName string,
+	sc *models.Sidecar,
+	network models.Network,
+	subnetID ids.ID,
+) error
+
+// returns the post commit hook for the type of the given tx
+func getPostCommitHook(tx *txs.Tx) postCommitHook {
+	switch tx.Unsigned.(type) {
+	case *txs.CreateChainTx:
+		return createChainPostCommit
+	case *txs.AddSubnetValidatorTx:
+		return addSubnetValidatorPostCommit
+	case *txs.RemoveSubnetValidatorTx:
+		return removeSubnetValidatorPostCommit
+	case *txs.TransformSubnetTx:
+		return transformSubnetPostCommit
+	default:
+		return defaultPostCommit
+	}
+}
+
+func defaultPostCommit(
+	_ *txs.Tx,
+	txID ids.ID,
+	_ string,
+	_ *models.Sidecar,
+	_ models.Network,
+	_ ids.ID,
+) error {
+	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	return nil
+}
+
+// the blockchain ID is the ID of the create chain tx, so the deploy
+// can now be registered into the sidecar
+func createChainPostCommit(
+	_ *txs.Tx,
+	txID ids.ID,
+	subnetName string,
+	sc *models.Sidecar,
+	network models.Network,
+	subnetID ids.ID,
+) error {
+	if err := app.UpdateSidecarNetworks(sc, network, subnetID, txID); err != nil {
+		return err
+	}
+	return subnetcmd.PrintDeployResults(subnetName, subnetID, txID, true)
+}
+
+func addSubnetValidatorPostCommit(
+	tx *txs.Tx,
+	txID ids.ID,
+	_ string,
+	_ *models.Sidecar,
+	network models.Network,
+	_ ids.ID,
+) error {
+	unsignedTx, ok := tx.Unsigned.(*txs.AddSubnetValidatorTx)
+	if !ok {
+		return defaultPostCommit(tx, txID, "", nil, network, ids.Empty)
+	}
+	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("NodeID: %s", unsignedTx.Validator.NodeID.String())
+	ux.Logger.PrintToUser("Network: %s", network.String())
+	ux.Logger.PrintToUser("Start time: %s", unsignedTx.StartTime().UTC().Format(constants.TimeParseLayout))
+	ux.Logger.PrintToUser("End time: %s", unsignedTx.EndTime().UTC().Format(constants.TimeParseLayout))
+	ux.Logger.PrintToUser("Weight: %d", unsignedTx.Validator.Wght)
+	return nil
+}
+
+func removeSubnetValidatorPostCommit(
+	tx *txs.Tx,
+	txID ids.ID,
+	_ string,
+	_ *models.Sidecar,
+	network models.Network,
+	_ ids.ID,
+) error {
+	unsignedTx, ok := tx.Unsigned.(*txs.RemoveSubnetValidatorTx)
+	if !ok {
+		return defaultPostCommit(tx, txID, "", nil, network, ids.Empty)
+	}
+	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	ux.Logger.PrintToUser("Validator %s removed from subnet %s on %s",
+		unsignedTx.NodeID.String(),
+		unsignedTx.Subnet.String(),
+		network.String(),
+	)
+	return nil
+}
+
+func transformSubnetPostCommit(
+	tx *txs.Tx,
+	txID ids.ID,
+	_ string,
+	_ *models.Sidecar,
+	network models.Network,
+	_ ids.ID,
+) error {
+	unsignedTx, ok := tx.Unsigned.(*txs.TransformSubnetTx)
+	if !ok {
+		return defaultPostCommit(tx, txID, "", nil, network, ids.Empty)
+	}
+	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", txID)
+	ux.Logger.PrintToUser("Subnet %s transformed into an elastic subnet on %s", unsignedTx.Subnet.String(), network.String())
+	ux.Logger.PrintToUser("Asset ID: %s", unsignedTx.AssetID.String())
+	return nil
+}
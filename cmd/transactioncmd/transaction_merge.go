@@ -0,0 +1,103 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/txutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/spf13/cobra"
+)
+
+var outputTxPath string
+
+// avalanche transaction merge
+func newTransactionMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "merge [txFile1] [txFile2] ...",
+		Short: "merge signatures of several copies of a transaction",
+		Long: `The transaction merge command combines the signatures of several copies of the
+same multisig transaction, each one signed independently by different subnet auth keys,
+into a single transaction file. This enables the control keys to sign in parallel
+instead of sequentially.`,
+		RunE:         mergeTx,
+		Args:         cobra.MinimumNArgs(2),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&outputTxPath, "output-tx-path", "", "file path of the merged transaction")
+	return cmd
+}
+
+func mergeTx(_ *cobra.Command, args []string) error {
+	txsToMerge := []*txs.Tx{}
+	for _, txPath := range args {
+		tx, err := txutils.LoadFromDisk(txPath)
+		if err != nil {
+			return err
+		}
+		if !txutils.IsSubnetAuthTx(tx) {
+			return errNotSubnetAuthTx
+		}
+		txsToMerge = append(txsToMerge, tx)
+	}
+
+	tx, err := txutils.Merge(txsToMerge)
+	if err != nil {
+		return err
+	}
+
+	network, err := txutils.GetNetwork(tx)
+	if err != nil {
+		return err
+	}
+	subnetID, err := txutils.GetSubnetID(tx)
+	if err != nil {
+		return err
+	}
+
+	subnetAuthKeys, err := txutils.GetAuthSigners(app, tx, network, subnetID)
+	if err != nil {
+		return err
+	}
+
+	subnetName, err := getSubnetNameByID(network, subnetID)
+	if err != nil {
+		return err
+	}
+
+	ux.Logger.PrintToUser("Merged signatures of %d %s transaction files", len(args), txutils.GetTxName(tx))
+	return subnetcmd.SaveNotFullySignedTx(
+		txutils.GetTxName(tx),
+		tx,
+		network,
+		subnetName,
+		subnetID,
+		subnetAuthKeys,
+		outputTxPath,
+		false,
+	)
+}
+
+// finds the name of the local subnet configuration that was deployed
+// to [network] with [subnetID]. If not found, returns a placeholder to
+// be used on user messages
+func getSubnetNameByID(network models.Network, subnetID ids.ID) (string, error) {
+	subnetNames, err := app.GetSidecarNames()
+	if err != nil {
+		return "", err
+	}
+	for _, subnetName := range subnetNames {
+		sc, err := app.LoadSidecar(subnetName)
+		if err != nil {
+			return "", err
+		}
+		if sc.Networks[app.GetNetworkKey(network)].SubnetID == subnetID {
+			return subnetName, nil
+		}
+	}
+	return "[subnetName]", nil
+}
@@ -4,6 +4,7 @@ package transactioncmd
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -12,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/txutils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/spf13/cobra"
 )
 
@@ -23,15 +25,19 @@ var (
 	useLedger       bool
 	ledgerAddresses []string
 
-	errNoSubnetID = errors.New("failed to find the subnet ID for this subnet, has it been deployed/created on this network?")
+	errNoSubnetID       = errors.New("failed to find the subnet ID for this subnet, has it been deployed/created on this network?")
+	errNotSubnetAuthTx  = errors.New("the given tx does not require subnet auth signatures")
+	errSubnetIDMismatch = errors.New("the given tx does not operate on this subnet")
 )
 
 // avalanche transaction sign
 func newTransactionSignCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "sign [subnetName]",
-		Short:        "sign a transaction",
-		Long:         "The transaction sign command signs a multisig transaction.",
+		Use:   "sign [subnetName]",
+		Short: "sign a transaction",
+		Long: `The transaction sign command signs a multisig transaction. Supported transactions are
+the ones requiring subnet authorization: blockchain creation, add and remove subnet
+validator, and transform subnet.`,
 		RunE:         signTx,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
@@ -56,13 +62,8 @@ func signTx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-
-	if len(ledgerAddresses) > 0 {
-		useLedger = true
-	}
-
-	if useLedger && keyName != "" {
-		return subnetcmd.ErrMutuallyExlusiveKeyLedger
+	if !txutils.IsSubnetAuthTx(tx) {
+		return errNotSubnetAuthTx
 	}
 
 	// we need network to decide if ledger is forced (mainnet)
@@ -70,21 +71,8 @@ func signTx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	switch network {
-	case models.Fuji, models.Local:
-		if !useLedger && keyName == "" {
-			useLedger, keyName, err = prompts.GetFujiKeyOrLedger(app.Prompt, app.GetKeyDir())
-			if err != nil {
-				return err
-			}
-		}
-	case models.Mainnet:
-		useLedger = true
-		if keyName != "" {
-			return subnetcmd.ErrStoredKeyOnMainnet
-		}
-	default:
-		return errors.New("unsupported network")
+	if err := selectKeyOrLedger(network); err != nil {
+		return err
 	}
 
 	// we need subnet wallet signing validation + process
@@ -93,17 +81,28 @@ func signTx(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	subnetID := sc.Networks[network.String()].SubnetID
+	subnetID := sc.Networks[app.GetNetworkKey(network)].SubnetID
 	if subnetID == ids.Empty {
 		return errNoSubnetID
 	}
+	if err := checkTxSubnetID(tx, subnetID); err != nil {
+		return err
+	}
+
+	rebuilt, err := checkStaleTx(tx, network, subnetName, subnetID)
+	if err != nil {
+		return err
+	}
+	if rebuilt {
+		return nil
+	}
 
-	subnetAuthKeys, err := txutils.GetAuthSigners(tx, network, subnetID)
+	subnetAuthKeys, err := txutils.GetAuthSigners(app, tx, network, subnetID)
 	if err != nil {
 		return err
 	}
 
-	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(tx, network, subnetID)
+	remainingSubnetAuthKeys, err := txutils.GetRemainingSigners(app, tx, network, subnetID)
 	if err != nil {
 		return err
 	}
@@ -134,7 +133,7 @@ func signTx(_ *cobra.Command, args []string) error {
 	}
 
 	if err := subnetcmd.SaveNotFullySignedTx(
-		"Tx",
+		txutils.GetTxName(tx),
 		tx,
 		network,
 		subnetName,
@@ -148,3 +147,44 @@ func signTx(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// sets [useLedger] and [keyName] from flags, or by prompting the user if not given.
+// ledger usage is forced on mainnet
+func selectKeyOrLedger(network models.Network) error {
+	var err error
+	if len(ledgerAddresses) > 0 {
+		useLedger = true
+	}
+	if useLedger && keyName != "" {
+		return subnetcmd.ErrMutuallyExlusiveKeyLedger
+	}
+	switch network {
+	case models.Fuji, models.Local:
+		if !useLedger && keyName == "" {
+			useLedger, keyName, err = prompts.GetFujiKeyOrLedger(app.Prompt, app.GetKeyDir())
+			if err != nil {
+				return err
+			}
+		}
+	case models.Mainnet:
+		useLedger = true
+		if keyName != "" {
+			return subnetcmd.ErrStoredKeyOnMainnet
+		}
+	default:
+		return errors.New("unsupported network")
+	}
+	return nil
+}
+
+// verifies that the tx operates on the given subnet
+func checkTxSubnetID(tx *txs.Tx, subnetID ids.ID) error {
+	txSubnetID, err := txutils.GetSubnetID(tx)
+	if err != nil {
+		return err
+	}
+	if txSubnetID != subnetID {
+		return fmt.Errorf("%w: expected %s, got %s", errSubnetIDMismatch, subnetID, txSubnetID)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package transactioncmd
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanche-cli/cmd/subnetcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/txutils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var errStaleTx = errors.New("the tx is stale and can't be accepted by the P-Chain")
+
+// checks that [tx] can still be accepted by the P-Chain. If not, reports the
+// reasons and offers the user to rebuild it with fresh UTXOs. The rebuilt tx
+// is saved to [inputTxPath], replacing the stale one.
+// returns true if the tx was rebuilt
+func checkStaleTx(
+	tx *txs.Tx,
+	network models.Network,
+	subnetName string,
+	subnetID ids.ID,
+) (bool, error) {
+	report, err := txutils.CheckStaleness(app, tx, network, subnetID)
+	if err != nil {
+		return false, err
+	}
+	if !report.IsStale() {
+		return false, nil
+	}
+
+	ux.Logger.PrintToUser(logging.Yellow.Wrap("The transaction is stale and will be rejected by the P-Chain:"))
+	for _, utxoID := range report.SpentUTXOs {
+		ux.Logger.PrintToUser("  UTXO %s has already been spent", utxoID)
+	}
+	if report.SubnetAuthMismatch != "" {
+		ux.Logger.PrintToUser("  subnet owners changed: %s", report.SubnetAuthMismatch)
+	}
+	ux.Logger.PrintToUser("")
+	rebuild, err := app.Prompt.CaptureYesNo(
+		"Do you want to rebuild the tx with fresh UTXOs? All the subnet auth signatures will need to be done again",
+	)
+	if err != nil {
+		return false, err
+	}
+	if !rebuild {
+		return false, errStaleTx
+	}
+
+	var subnetAuthKeys []string
+	if report.SubnetAuthMismatch == "" {
+		subnetAuthKeys, err = txutils.GetAuthSigners(app, tx, network, subnetID)
+		if err != nil {
+			return false, err
+		}
+	} else {
+		controlKeys, threshold, err := subnet.GetOwners(app, network, subnetID)
+		if err != nil {
+			return false, err
+		}
+		subnetAuthKeys, err = prompts.GetSubnetAuthKeys(app.Prompt, controlKeys, threshold)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := selectKeyOrLedger(network); err != nil {
+		return false, err
+	}
+	kc, err := subnetcmd.GetKeychain(useLedger, ledgerAddresses, keyName, network)
+	if err != nil {
+		return false, err
+	}
+	deployer := subnet.NewPublicDeployer(app, useLedger, kc, network)
+	newTx, err := deployer.Rebuild(tx, subnetAuthKeys, subnetID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := subnetcmd.SaveNotFullySignedTx(
+		txutils.GetTxName(newTx),
+		newTx,
+		network,
+		subnetName,
+		subnetID,
+		subnetAuthKeys,
+		inputTxPath,
+		true,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/ava-labs/apm/apm"
 	"github.com/ava-labs/avalanche-cli/pkg/config"
@@ -30,6 +31,17 @@ type Avalanche struct {
 	Apm        *apm.APM
 	ApmDir     string
 	Downloader Downloader
+	// version of the CLI, empty for builds without a release version
+	Version string
+	// refuse to install binaries whose releases publish no checksums
+	RequireChecksums bool
+
+	// local network targeted by this process, overrides the selected one
+	localNetworkName string
+
+	// file locks held by this process
+	locksMu sync.Mutex
+	locks   map[string]*heldLock
 }
 
 func New() *Avalanche {
@@ -49,7 +61,7 @@ func (app *Avalanche) GetRunFile() string {
 }
 
 func (app *Avalanche) GetSnapshotsDir() string {
-	return filepath.Join(app.baseDir, constants.SnapshotsDirName)
+	return filepath.Join(app.GetLocalNetworkDir(), constants.SnapshotsDirName)
 }
 
 func (app *Avalanche) GetBaseDir() string {
@@ -65,7 +77,11 @@ func (app *Avalanche) GetReposDir() string {
 }
 
 func (app *Avalanche) GetRunDir() string {
-	return filepath.Join(app.baseDir, constants.RunDir)
+	return filepath.Join(app.GetLocalNetworkDir(), constants.RunDir)
+}
+
+func (app *Avalanche) GetMetricsDir() string {
+	return filepath.Join(app.GetLocalNetworkDir(), constants.MetricsDir)
 }
 
 func (app *Avalanche) GetCustomVMDir() string {
@@ -73,7 +89,7 @@ func (app *Avalanche) GetCustomVMDir() string {
 }
 
 func (app *Avalanche) GetPluginsDir() string {
-	return filepath.Join(app.baseDir, constants.PluginDir)
+	return filepath.Join(app.GetLocalNetworkDir(), constants.PluginDir)
 }
 
 func (app *Avalanche) GetAvalanchegoBinDir() string {
@@ -92,6 +108,12 @@ func (app *Avalanche) GetUpgradeBytesFilepath(subnetName string) string {
 	return filepath.Join(app.GetSubnetDir(), subnetName, constants.UpgradeBytesFileName)
 }
 
+// GetVMBackupPath returns the path where the VM binary replaced on the last
+// VM upgrade of [subnetName] on [network] is kept
+func (app *Avalanche) GetVMBackupPath(subnetName string, network string) string {
+	return filepath.Join(app.GetSubnetDir(), subnetName, constants.VMBackupDir, network)
+}
+
 func (app *Avalanche) GetCustomVMPath(subnetName string) string {
 	return filepath.Join(app.GetCustomVMDir(), subnetName)
 }
@@ -159,19 +181,25 @@ func (app *Avalanche) ReadLockUpgradeFile(subnetName string) ([]byte, error) {
 func (app *Avalanche) WriteUpgradeFile(subnetName string, bytes []byte) error {
 	upgradeBytesFilePath := app.GetUpgradeBytesFilePath(subnetName)
 
-	return app.writeFile(upgradeBytesFilePath, bytes)
+	return app.withLock(subnetLockPrefix+subnetName, func() error {
+		return app.writeFile(upgradeBytesFilePath, bytes)
+	})
 }
 
 func (app *Avalanche) WriteLockUpgradeFile(subnetName string, bytes []byte) error {
 	upgradeBytesLockFilePath := app.GetUpgradeBytesFilePath(subnetName) + constants.UpgradeBytesLockExtension
 
-	return app.writeFile(upgradeBytesLockFilePath, bytes)
+	return app.withLock(subnetLockPrefix+subnetName, func() error {
+		return app.writeFile(upgradeBytesLockFilePath, bytes)
+	})
 }
 
 func (app *Avalanche) WriteGenesisFile(subnetName string, genesisBytes []byte) error {
 	genesisPath := app.GetGenesisPath(subnetName)
 
-	return app.writeFile(genesisPath, genesisBytes)
+	return app.withLock(subnetLockPrefix+subnetName, func() error {
+		return app.writeFile(genesisPath, genesisBytes)
+	})
 }
 
 func (app *Avalanche) GenesisExists(subnetName string) bool {
@@ -207,7 +235,9 @@ func (app *Avalanche) CopyGenesisFile(inputFilename string, subnetName string) e
 		return err
 	}
 
-	return os.WriteFile(genesisPath, genesisBytes, WriteReadReadPerms)
+	return app.withLock(subnetLockPrefix+subnetName, func() error {
+		return os.WriteFile(genesisPath, genesisBytes, WriteReadReadPerms)
+	})
 }
 
 func (app *Avalanche) CopyVMBinary(inputFilename string, subnetName string) error {
@@ -216,7 +246,9 @@ func (app *Avalanche) CopyVMBinary(inputFilename string, subnetName string) erro
 		return err
 	}
 	vmPath := app.GetCustomVMPath(subnetName)
-	return os.WriteFile(vmPath, vmBytes, WriteReadReadPerms)
+	return app.withLock(subnetLockPrefix+subnetName, func() error {
+		return os.WriteFile(vmPath, vmBytes, WriteReadReadPerms)
+	})
 }
 
 func (app *Avalanche) CopyKeyFile(inputFilename string, keyName string) error {
@@ -225,7 +257,9 @@ func (app *Avalanche) CopyKeyFile(inputFilename string, keyName string) error {
 		return err
 	}
 	keyPath := app.GetKeyPath(keyName)
-	return os.WriteFile(keyPath, keyBytes, WriteReadReadPerms)
+	return app.withLock(keyLockPrefix+keyName, func() error {
+		return os.WriteFile(keyPath, keyBytes, WriteReadReadPerms)
+	})
 }
 
 func (app *Avalanche) LoadEvmGenesis(subnetName string) (core.Genesis, error) {
@@ -267,7 +301,9 @@ func (app *Avalanche) CreateSidecar(sc *models.Sidecar) error {
 		return err
 	}
 
-	return os.WriteFile(sidecarPath, scBytes, WriteReadReadPerms)
+	return app.withLock(subnetLockPrefix+sc.Name, func() error {
+		return os.WriteFile(sidecarPath, scBytes, WriteReadReadPerms)
+	})
 }
 
 func (app *Avalanche) LoadSidecar(subnetName string) (models.Sidecar, error) {
@@ -284,9 +320,28 @@ func (app *Avalanche) LoadSidecar(subnetName string) (models.Sidecar, error) {
 		sc.TokenName = constants.DefaultTokenName
 	}
 
+	// local deployments were recorded under a single key when the only local
+	// network was the default one. The migrated sidecar is written on the next update
+	if networkData, ok := sc.Networks[models.Local.String()]; ok {
+		defaultKey := models.LocalNetworkKey(constants.DefaultLocalNetworkName)
+		if _, ok := sc.Networks[defaultKey]; !ok {
+			sc.Networks[defaultKey] = networkData
+		}
+		delete(sc.Networks, models.Local.String())
+	}
+
 	return sc, err
 }
 
+// GetNetworkKey returns the key of the deployment data on [network] in sidecars.
+// For the local network, it is the one of the target local network
+func (app *Avalanche) GetNetworkKey(network models.Network) string {
+	if network == models.Local {
+		return models.LocalNetworkKey(app.GetLocalNetworkName())
+	}
+	return network.String()
+}
+
 func (app *Avalanche) UpdateSidecar(sc *models.Sidecar) error {
 	sc.Version = constants.SidecarVersion
 	scBytes, err := json.MarshalIndent(sc, "", "    ")
@@ -295,7 +350,9 @@ func (app *Avalanche) UpdateSidecar(sc *models.Sidecar) error {
 	}
 
 	sidecarPath := app.GetSidecarPath(sc.Name)
-	return os.WriteFile(sidecarPath, scBytes, WriteReadReadPerms)
+	return app.withLock(subnetLockPrefix+sc.Name, func() error {
+		return os.WriteFile(sidecarPath, scBytes, WriteReadReadPerms)
+	})
 }
 
 func (app *Avalanche) UpdateSidecarNetworks(
@@ -307,9 +364,10 @@ func (app *Avalanche) UpdateSidecarNetworks(
 	if sc.Networks == nil {
 		sc.Networks = make(map[string]models.NetworkData)
 	}
-	sc.Networks[network.String()] = models.NetworkData{
+	sc.Networks[app.GetNetworkKey(network)] = models.NetworkData{
 		SubnetID:     subnetID,
 		BlockchainID: blockchainID,
+		VMVersion:    sc.VMVersion,
 	}
 	if err := app.UpdateSidecar(sc); err != nil {
 		return fmt.Errorf("creation of chains and subnet was successful, but failed to update sidecar: %w", err)
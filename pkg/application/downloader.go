@@ -5,16 +5,33 @@ package application
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"golang.org/x/mod/semver"
 )
 
-const githubVersionTagName = "tag_name"
+const (
+	githubVersionTagName = "tag_name"
+
+	fileScheme      = "file"
+	githubAPIHost   = "api.github.com"
+	mirrorAPISuffix = ".json"
+)
+
+var (
+	ErrOffline       = errors.New("not available in offline mode")
+	ErrInvalidMirror = errors.New("invalid release mirror")
+)
 
 // This is a generic interface for performing highly testable downloads. All methods here involve
 // external http requests. To write tests using these functions, provide a mocked version of this
@@ -25,29 +42,154 @@ type Downloader interface {
 	GetAllReleasesForRepo(org, repo string) ([]string, error)
 }
 
-type downloader struct{}
+type downloader struct {
+	// base URL of a release mirror replacing GitHub, if any
+	mirror string
+	// only read local files: installed binaries, cached compatibility data and a file mirror
+	offline bool
+	// base dir of the installed binaries and of the compatibility data cache
+	baseDir string
+}
 
 func NewDownloader() Downloader {
 	return &downloader{}
 }
 
-func (downloader) Download(url string) ([]byte, error) {
-	resp, err := http.Get(url)
+// NewConfiguredDownloader returns a Downloader that gets releases and compatibility data
+// from [mirror] instead of GitHub, if given, and caches compatibility data under [baseDir].
+// In [offline] mode, versions are resolved from the binaries installed under [baseDir],
+// compatibility data is read from the cache, and downloads only succeed from a file:// mirror
+func NewConfiguredDownloader(baseDir string, mirror string, offline bool) (Downloader, error) {
+	if mirror != "" {
+		mirrorURL, err := url.Parse(mirror)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %s", ErrInvalidMirror, mirror, err)
+		}
+		switch mirrorURL.Scheme {
+		case "http", "https", fileScheme:
+		default:
+			return nil, fmt.Errorf("%w %q: expected an http, https or file URL", ErrInvalidMirror, mirror)
+		}
+	}
+	return &downloader{
+		mirror:  strings.TrimSuffix(mirror, "/"),
+		offline: offline,
+		baseDir: baseDir,
+	}, nil
+}
+
+// GetMirrorPath returns the path relative to a release mirror of the file served by
+// GitHub at [rawURL]: its host followed by its path, as github.com/ava-labs/avalanchego/
+// releases/download/v1.9.4/avalanchego-linux-amd64-v1.9.4.tar.gz. GitHub API responses
+// get a .json suffix, as api.github.com/repos/ava-labs/avalanchego/releases/latest.json
+func GetMirrorPath(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Host == "" || u.Path == "" {
+		return "", fmt.Errorf("unexpected release URL %q", rawURL)
+	}
+	mirrorPath := u.Host + u.Path
+	if u.Host == githubAPIHost {
+		mirrorPath += mirrorAPISuffix
+	}
+	return mirrorPath, nil
+}
+
+// returns the URL [rawURL] is read from, at the mirror if any
+func (d downloader) resolve(rawURL string) (string, error) {
+	if d.mirror == "" {
+		return rawURL, nil
+	}
+	mirrorPath, err := GetMirrorPath(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return d.mirror + "/" + mirrorPath, nil
+}
+
+// opens [rawURL], reading it at the mirror if any
+func (d downloader) open(rawURL string, token string) (io.ReadCloser, error) {
+	sourceURL, err := d.resolve(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	source, err := url.Parse(sourceURL)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if source.Scheme == fileScheme {
+		return os.Open(source.Path)
+	}
+	if d.offline {
+		return nil, fmt.Errorf("%w: can't download %s", ErrOffline, rawURL)
+	}
+	request, err := http.NewRequest("GET", sourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", sourceURL, err)
+	}
+	if token != "" && d.mirror == "" {
+		// avoid rate limitation issues at CI
+		request.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
+	}
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed doing request to %s: %w", sourceURL, err)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected http status code: %d", resp.StatusCode)
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed doing request %s: unexpected http status code: %d", sourceURL, resp.StatusCode)
 	}
+	return resp.Body, nil
+}
 
-	return io.ReadAll(resp.Body)
+func (d downloader) Download(url string) ([]byte, error) {
+	cached := d.baseDir != "" && isCompatibilityURL(url)
+	if cached && d.offline {
+		return d.readCache(url)
+	}
+	b, err := d.download(url)
+	if err != nil {
+		if cached {
+			// fall back to the last downloaded data
+			if cachedBytes, cacheErr := d.readCache(url); cacheErr == nil {
+				return cachedBytes, nil
+			}
+		}
+		return nil, err
+	}
+	if cached {
+		// the cache is only a fallback, failing to update it is not an error
+		_ = d.writeCache(url, b)
+	}
+	return b, nil
+}
+
+func (d downloader) download(url string) ([]byte, error) {
+	body, err := d.open(url, "")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return io.ReadAll(body)
 }
 
 func (d downloader) GetAllReleasesForRepo(org, repo string) ([]string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", org, repo)
+	if d.offline {
+		versions, err := d.getInstalledVersions(repo)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) == 0 {
+			return nil, fmt.Errorf("%w: no %s version installed", ErrOffline, repo)
+		}
+		return versions, nil
+	}
+
+	url := fmt.Sprintf("https://%s/repos/%s/%s/releases", githubAPIHost, org, repo)
 	token := os.Getenv(constants.GithubAPITokenEnvVarName)
-	body, err := d.doAPIRequest(url, token)
+	body, err := d.open(url, token)
 	if err != nil {
 		return nil, err
 	}
@@ -75,31 +217,27 @@ func (d downloader) GetAllReleasesForRepo(org, repo string) ([]string, error) {
 	return releases, nil
 }
 
-func (downloader) doAPIRequest(url, token string) (io.ReadCloser, error) {
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
-	}
-	if token != "" {
-		// avoid rate limitation issues at CI
-		request.Header.Set("authorization", fmt.Sprintf("Bearer %s", token))
-	}
-	resp, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return nil, fmt.Errorf("failed doing request to %s: %w", url, err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed doing request %s: unexpected http status code: %d", url, resp.StatusCode)
-	}
-	return resp.Body, nil
-}
-
 // GetLatestReleaseVersion returns the latest available version from github
 func (d downloader) GetLatestReleaseVersion(releaseURL string) (string, error) {
 	// TODO: Question if there is a less error prone (= simpler) way to install latest avalanchego
 	// Maybe the binary package manager should also allow the actual avalanchego binary for download
+	if d.offline {
+		repo, err := getRepoFromReleaseURL(releaseURL)
+		if err != nil {
+			return "", err
+		}
+		versions, err := d.getInstalledVersions(repo)
+		if err != nil {
+			return "", err
+		}
+		if len(versions) == 0 {
+			return "", fmt.Errorf("%w: no %s version installed to use as latest", ErrOffline, repo)
+		}
+		return versions[0], nil
+	}
+
 	token := os.Getenv(constants.GithubAPITokenEnvVarName)
-	body, err := d.doAPIRequest(releaseURL, token)
+	body, err := d.open(releaseURL, token)
 	if err != nil {
 		return "", err
 	}
@@ -122,3 +260,92 @@ func (d downloader) GetLatestReleaseVersion(releaseURL string) (string, error) {
 
 	return version, nil
 }
+
+// returns the versions of [repo] installed under the bin dir, latest first
+func (d downloader) getInstalledVersions(repo string) ([]string, error) {
+	var installDir string
+	switch repo {
+	case constants.AvalancheGoRepoName:
+		installDir = constants.AvalancheGoInstallDir
+	case constants.SubnetEVMRepoName:
+		installDir = constants.SubnetEVMInstallDir
+	case constants.SpacesVMRepoName:
+		installDir = constants.SpacesVMInstallDir
+	default:
+		return nil, fmt.Errorf("%w: unknown binary %s", ErrOffline, repo)
+	}
+	entries, err := os.ReadDir(filepath.Join(d.baseDir, constants.AvalancheCliBinDir, installDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	versions := []string{}
+	for _, entry := range entries {
+		version := strings.TrimPrefix(entry.Name(), installDir+"-")
+		if entry.IsDir() && version != entry.Name() && semver.IsValid(version) {
+			versions = append(versions, version)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return semver.Compare(versions[i], versions[j]) > 0
+	})
+	return versions, nil
+}
+
+func (d downloader) getCachePath(rawURL string) (string, error) {
+	mirrorPath, err := GetMirrorPath(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d.baseDir, constants.DownloadCacheDir, filepath.FromSlash(mirrorPath)), nil
+}
+
+func (d downloader) readCache(rawURL string) ([]byte, error) {
+	cachePath, err := d.getCachePath(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(cachePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s was never downloaded", ErrOffline, rawURL)
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (d downloader) writeCache(rawURL string, b []byte) error {
+	cachePath, err := d.getCachePath(rawURL)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	return os.WriteFile(cachePath, b, perms.ReadWrite)
+}
+
+// returns the name of the repo of a GitHub API release URL, as
+// https://api.github.com/repos/ava-labs/avalanchego/releases/latest
+func getRepoFromReleaseURL(releaseURL string) (string, error) {
+	u, err := url.Parse(releaseURL)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "repos" {
+		return "", fmt.Errorf("unexpected release URL %q", releaseURL)
+	}
+	return parts[2], nil
+}
+
+// compatibility data is cached to be available offline
+func isCompatibilityURL(rawURL string) bool {
+	switch rawURL {
+	case constants.AvalancheGoCompatibilityURL,
+		constants.SubnetEVMRPCCompatibilityURL,
+		constants.SpacesVMRPCCompatibilityURL:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testArchiveURL = "https://github.com/ava-labs/subnet-evm/releases/download/v0.4.8/subnet-evm_0.4.8_linux_amd64.tar.gz"
+	testLatestURL  = "https://api.github.com/repos/ava-labs/subnet-evm/releases/latest"
+)
+
+func writeTestFile(t *testing.T, path string, b []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), constants.DefaultPerms755))
+	require.NoError(t, os.WriteFile(path, b, constants.DefaultPerms755))
+}
+
+func TestGetMirrorPath(t *testing.T) {
+	require := require.New(t)
+	mirrorPath, err := GetMirrorPath(testArchiveURL)
+	require.NoError(err)
+	require.Equal("github.com/ava-labs/subnet-evm/releases/download/v0.4.8/subnet-evm_0.4.8_linux_amd64.tar.gz", mirrorPath)
+	mirrorPath, err = GetMirrorPath(testLatestURL)
+	require.NoError(err)
+	require.Equal("api.github.com/repos/ava-labs/subnet-evm/releases/latest.json", mirrorPath)
+	_, err = GetMirrorPath("not a url")
+	require.Error(err)
+}
+
+func TestFileMirror(t *testing.T) {
+	require := require.New(t)
+	mirrorDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mirrorDir, "github.com/ava-labs/subnet-evm/releases/download/v0.4.8/subnet-evm_0.4.8_linux_amd64.tar.gz"), []byte("archive"))
+	writeTestFile(t, filepath.Join(mirrorDir, "api.github.com/repos/ava-labs/subnet-evm/releases/latest.json"), []byte(`{"tag_name": "v0.4.8"}`))
+	writeTestFile(t, filepath.Join(mirrorDir, "api.github.com/repos/ava-labs/subnet-evm/releases.json"), []byte(`[{"tag_name": "v0.4.8"}, {"tag_name": "v0.4.7"}]`))
+
+	// a file mirror is usable offline
+	d, err := NewConfiguredDownloader(t.TempDir(), "file://"+mirrorDir+"/", true)
+	require.NoError(err)
+	b, err := d.Download(testArchiveURL)
+	require.NoError(err)
+	require.Equal([]byte("archive"), b)
+
+	d, err = NewConfiguredDownloader(t.TempDir(), "file://"+mirrorDir, false)
+	require.NoError(err)
+	version, err := d.GetLatestReleaseVersion(testLatestURL)
+	require.NoError(err)
+	require.Equal("v0.4.8", version)
+	versions, err := d.GetAllReleasesForRepo(constants.AvaLabsOrg, constants.SubnetEVMRepoName)
+	require.NoError(err)
+	require.Equal([]string{"v0.4.8", "v0.4.7"}, versions)
+
+	_, err = NewConfiguredDownloader(t.TempDir(), "ftp://mirror", false)
+	require.ErrorIs(err, ErrInvalidMirror)
+}
+
+func TestOffline(t *testing.T) {
+	require := require.New(t)
+	baseDir := t.TempDir()
+	for _, version := range []string{"v0.4.7", "v0.4.10", "v0.4.8"} {
+		require.NoError(os.MkdirAll(filepath.Join(baseDir, constants.AvalancheCliBinDir, constants.SubnetEVMInstallDir,
+			constants.SubnetEVMInstallDir+"-"+version), constants.DefaultPerms755))
+	}
+	d, err := NewConfiguredDownloader(baseDir, "", true)
+	require.NoError(err)
+
+	version, err := d.GetLatestReleaseVersion(testLatestURL)
+	require.NoError(err)
+	require.Equal("v0.4.10", version)
+	versions, err := d.GetAllReleasesForRepo(constants.AvaLabsOrg, constants.SubnetEVMRepoName)
+	require.NoError(err)
+	require.Equal([]string{"v0.4.10", "v0.4.8", "v0.4.7"}, versions)
+	_, err = d.GetLatestReleaseVersion("https://api.github.com/repos/ava-labs/spacesvm/releases/latest")
+	require.ErrorIs(err, ErrOffline)
+
+	_, err = d.Download(testArchiveURL)
+	require.ErrorIs(err, ErrOffline)
+	_, err = d.Download(constants.SubnetEVMRPCCompatibilityURL)
+	require.ErrorIs(err, ErrOffline)
+	// compatibility data downloaded before going offline
+	compatibilityPath, err := GetMirrorPath(constants.SubnetEVMRPCCompatibilityURL)
+	require.NoError(err)
+	writeTestFile(t, filepath.Join(baseDir, constants.DownloadCacheDir, filepath.FromSlash(compatibilityPath)), []byte(`{"rpcChainVMProtocolVersion": {}}`))
+	b, err := d.Download(constants.SubnetEVMRPCCompatibilityURL)
+	require.NoError(err)
+	require.Equal([]byte(`{"rpcChainVMProtocolVersion": {}}`), b)
+}
@@ -0,0 +1,228 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+)
+
+var (
+	ErrLocalNetworkNotFound    = errors.New("local network not found, start it first with `avalanche network start --name`")
+	ErrInvalidLocalNetworkName = errors.New("local network names may only contain letters, digits, '-' and '_'")
+
+	errNoFreePorts = errors.New("no free ports left for the backend of a new local network")
+
+	localNetworkNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+)
+
+const maxPort = 65535
+
+// GetLocalNetworkName returns the name of the local network local commands target:
+// the one set for this process with UseLocalNetwork, or else the selected one
+func (app *Avalanche) GetLocalNetworkName() string {
+	if app.localNetworkName != "" {
+		return app.localNetworkName
+	}
+	selected, err := os.ReadFile(app.getSelectedLocalNetworkPath())
+	if err != nil || strings.TrimSpace(string(selected)) == "" {
+		return constants.DefaultLocalNetworkName
+	}
+	return strings.TrimSpace(string(selected))
+}
+
+// UseLocalNetwork makes this process target local network [name], without
+// changing the selected one
+func (app *Avalanche) UseLocalNetwork(name string) {
+	app.localNetworkName = name
+}
+
+// SelectLocalNetwork makes local network [name] the target of local commands
+func (app *Avalanche) SelectLocalNetwork(name string) error {
+	if err := ValidateLocalNetworkName(name); err != nil {
+		return err
+	}
+	selectedPath := app.getSelectedLocalNetworkPath()
+	if err := os.MkdirAll(filepath.Dir(selectedPath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(selectedPath, []byte(name), WriteReadReadPerms); err != nil {
+		return err
+	}
+	app.localNetworkName = name
+	return nil
+}
+
+func ValidateLocalNetworkName(name string) error {
+	if !localNetworkNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidLocalNetworkName, name)
+	}
+	return nil
+}
+
+// GetLocalNetworkDir returns the dir holding the run files and snapshots of the
+// target local network. The default local network uses the base dir
+func (app *Avalanche) GetLocalNetworkDir() string {
+	return app.getLocalNetworkDir(app.GetLocalNetworkName())
+}
+
+func (app *Avalanche) getLocalNetworkDir(name string) string {
+	if name == constants.DefaultLocalNetworkName {
+		return app.baseDir
+	}
+	return filepath.Join(app.baseDir, constants.LocalNetworksDir, name)
+}
+
+func (app *Avalanche) getSelectedLocalNetworkPath() string {
+	return filepath.Join(app.baseDir, constants.LocalNetworksDir, constants.SelectedLocalNetworkFile)
+}
+
+// GetLocalNetworkNames returns the names of all the local networks created so far,
+// including the default one
+func (app *Avalanche) GetLocalNetworkNames() ([]string, error) {
+	names := []string{constants.DefaultLocalNetworkName}
+	entries, err := os.ReadDir(filepath.Join(app.baseDir, constants.LocalNetworksDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return names, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && entry.Name() != constants.DefaultLocalNetworkName {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names[1:])
+	return names, nil
+}
+
+// LoadLocalNetwork returns the settings of the target local network
+func (app *Avalanche) LoadLocalNetwork() (models.LocalNetwork, error) {
+	return app.loadLocalNetwork(app.GetLocalNetworkName())
+}
+
+func (app *Avalanche) loadLocalNetwork(name string) (models.LocalNetwork, error) {
+	networkBytes, err := os.ReadFile(filepath.Join(app.getLocalNetworkDir(name), constants.LocalNetworkFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			if name == constants.DefaultLocalNetworkName {
+				return models.NewDefaultLocalNetwork(), nil
+			}
+			return models.LocalNetwork{}, fmt.Errorf("%w: %s", ErrLocalNetworkNotFound, name)
+		}
+		return models.LocalNetwork{}, err
+	}
+	var ln models.LocalNetwork
+	if err := json.Unmarshal(networkBytes, &ln); err != nil {
+		return models.LocalNetwork{}, fmt.Errorf("failed unmarshalling local network %s settings: %w", name, err)
+	}
+	return ln, nil
+}
+
+// CreateLocalNetwork returns the settings of local network [name], creating it if needed.
+// New networks get the first pair of backend ports not recorded by any other local
+// network and free on this machine
+func (app *Avalanche) CreateLocalNetwork(name string) (models.LocalNetwork, error) {
+	if err := ValidateLocalNetworkName(name); err != nil {
+		return models.LocalNetwork{}, err
+	}
+	ln, err := app.loadLocalNetwork(name)
+	if err == nil {
+		return ln, nil
+	}
+	if !errors.Is(err, ErrLocalNetworkNotFound) {
+		return models.LocalNetwork{}, err
+	}
+
+	names, err := app.GetLocalNetworkNames()
+	if err != nil {
+		return models.LocalNetwork{}, err
+	}
+	usedPorts := map[int]bool{}
+	for _, otherName := range names {
+		other, err := app.loadLocalNetwork(otherName)
+		if err != nil {
+			continue
+		}
+		usedPorts[other.GRPCPort] = true
+		usedPorts[other.GRPCGatewayPort] = true
+	}
+	port := constants.DefaultGRPCServerPort
+	for usedPorts[port] || usedPorts[port+1] || !isPortFree(port) || !isPortFree(port+1) {
+		port += 2
+		if port+1 > maxPort {
+			return models.LocalNetwork{}, errNoFreePorts
+		}
+	}
+	ln = models.LocalNetwork{
+		Name:            name,
+		GRPCPort:        port,
+		GRPCGatewayPort: port + 1,
+		APIEndpoint:     constants.LocalAPIEndpoint,
+	}
+	return ln, app.writeLocalNetwork(ln)
+}
+
+// SetLocalAPIEndpoint records [endpoint] as the API endpoint of the target local network
+func (app *Avalanche) SetLocalAPIEndpoint(endpoint string) error {
+	ln, err := app.LoadLocalNetwork()
+	if err != nil {
+		return err
+	}
+	if ln.APIEndpoint == endpoint {
+		return nil
+	}
+	ln.APIEndpoint = endpoint
+	return app.writeLocalNetwork(ln)
+}
+
+// GetLocalAPIEndpoint returns the API endpoint of the target local network
+func (app *Avalanche) GetLocalAPIEndpoint() string {
+	ln, err := app.LoadLocalNetwork()
+	if err != nil || ln.APIEndpoint == "" {
+		return constants.LocalAPIEndpoint
+	}
+	return ln.APIEndpoint
+}
+
+// GetAPIEndpoint returns the API endpoint of [network], which for the local
+// network is the one of the target local network
+func (app *Avalanche) GetAPIEndpoint(network models.Network) (string, error) {
+	if network == models.Local {
+		return app.GetLocalAPIEndpoint(), nil
+	}
+	return network.Endpoint()
+}
+
+// returns true if nothing listens on [port] on this machine
+func isPortFree(port int) bool {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
+func (app *Avalanche) writeLocalNetwork(ln models.LocalNetwork) error {
+	networkDir := app.getLocalNetworkDir(ln.Name)
+	if err := os.MkdirAll(networkDir, constants.DefaultPerms755); err != nil {
+		return err
+	}
+	networkBytes, err := json.MarshalIndent(ln, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(networkDir, constants.LocalNetworkFileName), networkBytes, WriteReadReadPerms)
+}
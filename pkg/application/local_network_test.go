@@ -0,0 +1,114 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalNetworks(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+
+	// default network
+	require.Equal(constants.DefaultLocalNetworkName, ap.GetLocalNetworkName())
+	require.Equal(filepath.Join(ap.GetBaseDir(), constants.RunDir), ap.GetRunDir())
+	ln, err := ap.LoadLocalNetwork()
+	require.NoError(err)
+	require.Equal(constants.DefaultGRPCServerPort, ln.GRPCPort)
+	require.Equal(constants.LocalAPIEndpoint, ap.GetLocalAPIEndpoint())
+
+	// named networks get their own dir and ports
+	scratch, err := ap.CreateLocalNetwork("scratch")
+	require.NoError(err)
+	require.Greater(scratch.GRPCPort, constants.DefaultGRPCGatewayPort)
+	require.Equal(scratch.GRPCPort+1, scratch.GRPCGatewayPort)
+	other, err := ap.CreateLocalNetwork("other")
+	require.NoError(err)
+	require.Greater(other.GRPCPort, scratch.GRPCGatewayPort)
+	again, err := ap.CreateLocalNetwork("scratch")
+	require.NoError(err)
+	require.Equal(scratch, again)
+
+	names, err := ap.GetLocalNetworkNames()
+	require.NoError(err)
+	require.Equal([]string{constants.DefaultLocalNetworkName, "other", "scratch"}, names)
+
+	require.NoError(ap.SelectLocalNetwork("scratch"))
+	require.Equal("scratch", ap.GetLocalNetworkName())
+	require.Equal(filepath.Join(ap.GetBaseDir(), constants.LocalNetworksDir, "scratch", constants.RunDir), ap.GetRunDir())
+	require.NoError(ap.SetLocalAPIEndpoint("http://127.0.0.1:9660"))
+	require.Equal("http://127.0.0.1:9660", ap.GetLocalAPIEndpoint())
+
+	// a process override doesn't change the selection
+	ap.UseLocalNetwork(constants.DefaultLocalNetworkName)
+	require.Equal(constants.LocalAPIEndpoint, ap.GetLocalAPIEndpoint())
+	ap.UseLocalNetwork("")
+	require.Equal("scratch", ap.GetLocalNetworkName())
+
+	// ports in use on this machine are skipped
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", other.GRPCGatewayPort+1))
+	require.NoError(err)
+	defer listener.Close()
+	third, err := ap.CreateLocalNetwork("third")
+	require.NoError(err)
+	require.Greater(third.GRPCPort, other.GRPCGatewayPort+1)
+
+	_, err = ap.CreateLocalNetwork("bad/name")
+	require.ErrorIs(err, ErrInvalidLocalNetworkName)
+	ap.UseLocalNetwork("missing")
+	_, err = ap.LoadLocalNetwork()
+	require.ErrorIs(err, ErrLocalNetworkNotFound)
+}
+
+func TestLocalNetworkSidecarKeys(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+	sc := &models.Sidecar{Name: subnetName1, VM: models.SubnetEvm}
+	require.NoError(ap.CreateSidecar(sc))
+
+	// each local network keeps its own deployment data
+	_, err := ap.CreateLocalNetwork("stable")
+	require.NoError(err)
+	ap.UseLocalNetwork("stable")
+	stableID := ids.GenerateTestID()
+	require.NoError(ap.UpdateSidecarNetworks(sc, models.Local, ids.GenerateTestID(), stableID))
+	_, err = ap.CreateLocalNetwork("scratch")
+	require.NoError(err)
+	ap.UseLocalNetwork("scratch")
+	scratchID := ids.GenerateTestID()
+	require.NoError(ap.UpdateSidecarNetworks(sc, models.Local, ids.GenerateTestID(), scratchID))
+
+	loaded, err := ap.LoadSidecar(subnetName1)
+	require.NoError(err)
+	require.Equal(scratchID, loaded.Networks[ap.GetNetworkKey(models.Local)].BlockchainID)
+	ap.UseLocalNetwork("stable")
+	require.Equal(stableID, loaded.Networks[ap.GetNetworkKey(models.Local)].BlockchainID)
+	require.Equal(models.Fuji.String(), ap.GetNetworkKey(models.Fuji))
+
+	// the single local entry of older sidecars belongs to the default network
+	legacyID := ids.GenerateTestID()
+	legacy := models.Sidecar{
+		Name:     subnetName2,
+		VM:       models.SubnetEvm,
+		Networks: map[string]models.NetworkData{models.Local.String(): {BlockchainID: legacyID}},
+	}
+	legacyBytes, err := json.Marshal(legacy)
+	require.NoError(err)
+	require.NoError(os.MkdirAll(filepath.Dir(ap.GetSidecarPath(subnetName2)), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(ap.GetSidecarPath(subnetName2), legacyBytes, WriteReadReadPerms))
+	loaded, err = ap.LoadSidecar(subnetName2)
+	require.NoError(err)
+	require.NotContains(loaded.Networks, models.Local.String())
+	require.Equal(legacyID, loaded.Networks[models.LocalNetworkKey(constants.DefaultLocalNetworkName)].BlockchainID)
+}
@@ -0,0 +1,199 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"go.uber.org/zap"
+)
+
+const (
+	lockFileSuffix   = ".lock"
+	lockPollInterval = 200 * time.Millisecond
+
+	subnetLockPrefix       = "subnet-"
+	keyLockPrefix          = "key-"
+	localNetworkLockPrefix = "local-network-"
+)
+
+var ErrLockTimeout = errors.New("timed out waiting for lock")
+
+// LockHolder identifies the process holding a lock
+type LockHolder struct {
+	Pid        int       `json:"pid"`
+	Command    string    `json:"command"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+}
+
+func (h LockHolder) String() string {
+	return fmt.Sprintf("pid %d (%s) since %s", h.Pid, h.Command, h.AcquiredAt.Format(constants.TimeParseLayout))
+}
+
+// FileLock is a lock shared by all the CLI processes of the user, backed by
+// an flock on a file of the locks dir. The OS releases it if its holder dies
+type FileLock struct {
+	app  *Avalanche
+	name string
+}
+
+type heldLock struct {
+	file  *os.File
+	count int
+}
+
+// LockSubnet locks the artifacts of [subnetName]: sidecar, genesis, upgrade bytes and VM binary
+func (app *Avalanche) LockSubnet(subnetName string) (*FileLock, error) {
+	return app.Lock(subnetLockPrefix+subnetName, constants.LockTimeout)
+}
+
+// LockKey locks the key file of [keyName]
+func (app *Avalanche) LockKey(keyName string) (*FileLock, error) {
+	return app.Lock(keyLockPrefix+keyName, constants.LockTimeout)
+}
+
+// LockLocalNetwork locks the run file, backend, snapshots and plugins of the
+// target local network. Commands also locking a subnet must lock the local
+// network first, as LockLocalNetworkAndSubnet does, so they can't deadlock
+func (app *Avalanche) LockLocalNetwork() (*FileLock, error) {
+	return app.Lock(localNetworkLockPrefix+app.GetLocalNetworkName(), constants.LockTimeout)
+}
+
+// LockLocalNetworkAndSubnet locks the target local network and then [subnetName],
+// returning a function that releases both
+func (app *Avalanche) LockLocalNetworkAndSubnet(subnetName string) (func(), error) {
+	localNetworkLock, err := app.LockLocalNetwork()
+	if err != nil {
+		return nil, err
+	}
+	subnetLock, err := app.LockSubnet(subnetName)
+	if err != nil {
+		localNetworkLock.Release()
+		return nil, err
+	}
+	return func() {
+		subnetLock.Release()
+		localNetworkLock.Release()
+	}, nil
+}
+
+// Lock acquires lock [name], waiting up to [timeout] for other processes to release it.
+// Locks are reentrant within a process, each Lock call needing its own Release
+func (app *Avalanche) Lock(name string, timeout time.Duration) (*FileLock, error) {
+	app.locksMu.Lock()
+	if held, ok := app.locks[name]; ok {
+		held.count++
+		app.locksMu.Unlock()
+		return &FileLock{app: app, name: name}, nil
+	}
+	app.locksMu.Unlock()
+
+	lockPath := app.getLockPath(name)
+	if err := os.MkdirAll(filepath.Dir(lockPath), constants.DefaultPerms755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, WriteReadReadPerms)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	waiting := false
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed locking %s: %w", lockPath, err)
+		}
+		holder := readLockHolder(lockPath)
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, fmt.Errorf("%w %s, held by %s", ErrLockTimeout, name, holder)
+		}
+		if !waiting {
+			waiting = true
+			if ux.Logger != nil {
+				ux.Logger.PrintToUser("Waiting for %s, held by %s...", name, holder)
+			}
+		}
+		time.Sleep(lockPollInterval)
+	}
+
+	holderBytes, err := json.Marshal(LockHolder{
+		Pid:        os.Getpid(),
+		Command:    strings.Join(os.Args, " "),
+		AcquiredAt: time.Now(),
+	})
+	if err == nil {
+		if err := f.Truncate(0); err == nil {
+			_, err = f.WriteAt(holderBytes, 0)
+		}
+	}
+	if err != nil {
+		app.Log.Debug("failed recording lock holder", zap.String("lock", name), zap.Error(err))
+	}
+
+	app.locksMu.Lock()
+	defer app.locksMu.Unlock()
+	if app.locks == nil {
+		app.locks = map[string]*heldLock{}
+	}
+	app.locks[name] = &heldLock{file: f, count: 1}
+	return &FileLock{app: app, name: name}, nil
+}
+
+// Release releases the lock, once all its holders in this process released it
+func (l *FileLock) Release() {
+	l.app.locksMu.Lock()
+	defer l.app.locksMu.Unlock()
+	held, ok := l.app.locks[l.name]
+	if !ok {
+		return
+	}
+	held.count--
+	if held.count > 0 {
+		return
+	}
+	delete(l.app.locks, l.name)
+	if err := syscall.Flock(int(held.file.Fd()), syscall.LOCK_UN); err != nil {
+		l.app.Log.Debug("failed unlocking", zap.String("lock", l.name), zap.Error(err))
+	}
+	_ = held.file.Close()
+}
+
+// runs [f] holding lock [name]
+func (app *Avalanche) withLock(name string, f func() error) error {
+	lock, err := app.Lock(name, constants.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer lock.Release()
+	return f()
+}
+
+func (app *Avalanche) getLockPath(name string) string {
+	return filepath.Join(app.baseDir, constants.LocksDir, name+lockFileSuffix)
+}
+
+// returns the holder recorded in [lockPath], or an unknown one if it
+// can't be read, as when it's being written
+func readLockHolder(lockPath string) LockHolder {
+	holder := LockHolder{Command: "unknown command"}
+	holderBytes, err := os.ReadFile(lockPath)
+	if err != nil {
+		return holder
+	}
+	_ = json.Unmarshal(holderBytes, &holder)
+	return holder
+}
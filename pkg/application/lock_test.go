@@ -0,0 +1,74 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+
+	lock, err := ap.LockSubnet("subnet1")
+	require.NoError(err)
+	// reentrant within the process
+	inner, err := ap.LockSubnet("subnet1")
+	require.NoError(err)
+	inner.Release()
+	require.Contains(ap.locks, subnetLockPrefix+"subnet1")
+
+	holder := readLockHolder(ap.getLockPath(subnetLockPrefix + "subnet1"))
+	require.Equal(os.Getpid(), holder.Pid)
+
+	lock.Release()
+	require.NotContains(ap.locks, subnetLockPrefix+"subnet1")
+}
+
+func TestLockTimeout(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+
+	// simulate another process holding the lock with its own open file
+	lockPath := ap.getLockPath(keyLockPrefix + "key1")
+	require.NoError(os.MkdirAll(filepath.Dir(lockPath), constants.DefaultPerms755))
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, WriteReadReadPerms)
+	require.NoError(err)
+	defer f.Close()
+	require.NoError(syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB))
+	holderBytes, err := json.Marshal(LockHolder{Pid: 1234, Command: "avalanche subnet deploy", AcquiredAt: time.Now()})
+	require.NoError(err)
+	_, err = f.Write(holderBytes)
+	require.NoError(err)
+
+	_, err = ap.Lock(keyLockPrefix+"key1", 300*time.Millisecond)
+	require.ErrorIs(err, ErrLockTimeout)
+	require.ErrorContains(err, "pid 1234 (avalanche subnet deploy)")
+
+	require.NoError(syscall.Flock(int(f.Fd()), syscall.LOCK_UN))
+	lock, err := ap.Lock(keyLockPrefix+"key1", 300*time.Millisecond)
+	require.NoError(err)
+	lock.Release()
+}
+
+func TestLockLocalNetworkAndSubnet(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+
+	release, err := ap.LockLocalNetworkAndSubnet("subnet1")
+	require.NoError(err)
+	require.Contains(ap.locks, localNetworkLockPrefix+ap.GetLocalNetworkName())
+	require.Contains(ap.locks, subnetLockPrefix+"subnet1")
+
+	release()
+	require.NotContains(ap.locks, localNetworkLockPrefix+ap.GetLocalNetworkName())
+	require.NotContains(ap.locks, subnetLockPrefix+"subnet1")
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/docker/docker/pkg/reexec"
+	"github.com/shirou/gopsutil/process"
+	"go.uber.org/zap"
+)
+
+const (
+	backendPingTimeout  = 5 * time.Second
+	processStopTimeout  = 10 * time.Second
+	processPollInterval = 100 * time.Millisecond
+	devVersionPrefix    = "dev-"
+)
+
+// BackendStatus describes the backend of the run file of a local network
+type BackendStatus struct {
+	Pid        int
+	OutputPath string
+	// version of the CLI that started the backend
+	Version   string
+	StartedAt time.Time
+	// the backend answered a ping with the PID of the run file
+	Responding bool
+}
+
+// VersionMatches returns true if the backend was started by this version of the CLI
+func (s BackendStatus) VersionMatches(app *application.Avalanche) bool {
+	return s.Version == GetCLIVersion(app)
+}
+
+// GetCLIVersion returns the version of the CLI. Builds without a release version
+// are told apart by the modification time of their executable
+func GetCLIVersion(app *application.Avalanche) string {
+	if app.Version != "" {
+		return app.Version
+	}
+	info, err := os.Stat(reexec.Self())
+	if err != nil {
+		return devVersionPrefix
+	}
+	return devVersionPrefix + info.ModTime().UTC().Format("20060102150405")
+}
+
+// GetServerProcess returns the backend process of the run file. It returns
+// ErrBackendNotRunning if there is no run file, or if its process is gone or
+// is not a backend, as happens when the PID was reused after a crash
+func GetServerProcess(app *application.Avalanche) (*process.Process, error) {
+	rf, err := readRunFile(app)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: no run file", ErrBackendNotRunning)
+		}
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", ErrBackendNotRunning, err)
+	}
+	if rf.Pid == 0 {
+		return nil, fmt.Errorf("%w: no pid in run file %s", ErrBackendNotRunning, app.GetRunFile())
+	}
+	exists, err := process.PidExists(int32(rf.Pid))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: process %d is gone", ErrBackendNotRunning, rf.Pid)
+	}
+	proc, err := process.NewProcess(int32(rf.Pid))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBackendNotRunning, err)
+	}
+	cmdline, err := proc.Cmdline()
+	if err != nil {
+		// the process just died
+		return nil, fmt.Errorf("%w: %s", ErrBackendNotRunning, err)
+	}
+	if !strings.Contains(cmdline, constants.BackendCmd) {
+		return nil, fmt.Errorf("%w: process %d is not a backend", ErrBackendNotRunning, rf.Pid)
+	}
+	return proc, nil
+}
+
+// GetBackendStatus returns the status of the backend of the local network
+// targeted by [app], or ErrBackendNotRunning if it's not running
+func GetBackendStatus(app *application.Avalanche) (BackendStatus, error) {
+	if _, err := GetServerProcess(app); err != nil {
+		return BackendStatus{}, err
+	}
+	rf, err := readRunFile(app)
+	if err != nil {
+		return BackendStatus{}, err
+	}
+	status := BackendStatus{
+		Pid:        rf.Pid,
+		OutputPath: rf.GRPCserverFileName,
+		Version:    rf.Version,
+		StartedAt:  rf.StartedAt,
+	}
+	if err := pingBackend(app, rf.Pid); err != nil {
+		app.Log.Debug("backend handshake failed", zap.Error(err))
+	} else {
+		status.Responding = true
+	}
+	return status, nil
+}
+
+// checks that the backend listening on the gRPC port of the local network
+// is the process [pid]
+func pingBackend(app *application.Avalanche, pid int) error {
+	cli, err := NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), backendPingTimeout)
+	defer cancel()
+	resp, err := cli.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if int(resp.GetPid()) != pid {
+		return fmt.Errorf("gRPC port is served by process %d instead of %d", resp.GetPid(), pid)
+	}
+	return nil
+}
+
+// StopServerProcess stops the backend of the run file, killing it if it doesn't
+// exit in time, and removes the run file. Unlike KillgRPCServerProcess, it doesn't
+// stop the network first, so its nodes are left running
+func StopServerProcess(app *application.Avalanche) error {
+	proc, err := GetServerProcess(app)
+	switch {
+	case err == nil:
+		if err := StopProcesses([]*process.Process{proc}); err != nil {
+			return err
+		}
+	case !errors.Is(err, ErrBackendNotRunning):
+		return err
+	}
+	if err := os.Remove(app.GetRunFile()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// GetNodeProcesses returns the avalanchego processes running out of the run dir of
+// the local network targeted by [app]. When its backend is not running, these are
+// the nodes left behind by a backend that crashed
+func GetNodeProcesses(app *application.Avalanche) ([]*process.Process, error) {
+	runDir := app.GetRunDir() + string(filepath.Separator)
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*process.Process{}
+	for _, p := range procs {
+		cmdline, err := p.Cmdline()
+		if err != nil {
+			// ignore errors for processes that just died (macos implementation)
+			continue
+		}
+		if !strings.Contains(cmdline, runDir) || strings.Contains(cmdline, constants.BackendCmd) {
+			continue
+		}
+		exe, err := p.Exe()
+		if err != nil || !strings.Contains(filepath.Base(exe), constants.AvalancheGoRepoName) {
+			continue
+		}
+		nodes = append(nodes, p)
+	}
+	return nodes, nil
+}
+
+// StopProcesses terminates [procs], killing the ones that don't exit in time
+func StopProcesses(procs []*process.Process) error {
+	for _, p := range procs {
+		if err := p.Terminate(); err != nil {
+			if exists, _ := process.PidExists(p.Pid); exists {
+				return fmt.Errorf("failed terminating process %d: %w", p.Pid, err)
+			}
+		}
+	}
+	for _, p := range procs {
+		if waitForExit(p.Pid, processStopTimeout) {
+			continue
+		}
+		if err := p.Kill(); err != nil {
+			return fmt.Errorf("failed killing process %d: %w", p.Pid, err)
+		}
+		if !waitForExit(p.Pid, processStopTimeout) {
+			return fmt.Errorf("process %d did not exit after being killed", p.Pid)
+		}
+	}
+	return nil
+}
+
+func waitForExit(pid int32, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if exists, err := process.PidExists(pid); err == nil && !exists {
+			return true
+		}
+		time.Sleep(processPollInterval)
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package binutils
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/testutils"
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/stretchr/testify/require"
+)
+
+func newBackendTestApp(t *testing.T) *application.Avalanche {
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, &config.Config{}, prompts.NewPrompter(), application.NewDownloader())
+	return app
+}
+
+func writeTestRunFile(require *require.Assertions, app *application.Avalanche, content []byte) {
+	require.NoError(os.MkdirAll(filepath.Dir(app.GetRunFile()), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(app.GetRunFile(), content, perms.ReadWrite))
+}
+
+func writeTestRunFileWithPid(require *require.Assertions, app *application.Avalanche, pid int) {
+	content, err := json.Marshal(runFile{Pid: pid})
+	require.NoError(err)
+	writeTestRunFile(require, app, content)
+}
+
+// returns the pid of a process that already exited
+func getExitedPid(require *require.Assertions) int {
+	cmd := exec.Command("true")
+	require.NoError(cmd.Run())
+	return cmd.Process.Pid
+}
+
+// starts a process whose command line looks like the one of a backend
+func startFakeBackend(t *testing.T, require *require.Assertions) int {
+	cmd := exec.Command("sh", "-c", "while true; do sleep 1; done", constants.BackendCmd)
+	require.NoError(cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	return cmd.Process.Pid
+}
+
+func TestGetServerProcess(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(*testing.T, *require.Assertions, *application.Avalanche) int
+		expectedFail bool
+	}{
+		{
+			name: "no run file",
+			setup: func(*testing.T, *require.Assertions, *application.Avalanche) int {
+				return 0
+			},
+			expectedFail: true,
+		},
+		{
+			name: "malformed run file",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) int {
+				writeTestRunFile(require, app, []byte("{not json"))
+				return 0
+			},
+			expectedFail: true,
+		},
+		{
+			name: "no pid",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) int {
+				writeTestRunFileWithPid(require, app, 0)
+				return 0
+			},
+			expectedFail: true,
+		},
+		{
+			name: "missing pid",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) int {
+				writeTestRunFileWithPid(require, app, getExitedPid(require))
+				return 0
+			},
+			expectedFail: true,
+		},
+		{
+			name: "non backend pid",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) int {
+				writeTestRunFileWithPid(require, app, os.Getpid())
+				return 0
+			},
+			expectedFail: true,
+		},
+		{
+			name: "backend pid",
+			setup: func(t *testing.T, require *require.Assertions, app *application.Avalanche) int {
+				pid := startFakeBackend(t, require)
+				writeTestRunFileWithPid(require, app, pid)
+				return pid
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := testutils.SetupTest(t)
+			app := newBackendTestApp(t)
+			pid := tt.setup(t, require, app)
+
+			proc, err := GetServerProcess(app)
+			if tt.expectedFail {
+				require.ErrorIs(err, ErrBackendNotRunning)
+				return
+			}
+			require.NoError(err)
+			require.Equal(int32(pid), proc.Pid)
+		})
+	}
+}
+
+func TestIsServerProcessRunning(t *testing.T) {
+	tests := []struct {
+		name            string
+		setup           func(*testing.T, *require.Assertions, *application.Avalanche)
+		expectedRunning bool
+	}{
+		{
+			name:  "no run file",
+			setup: func(*testing.T, *require.Assertions, *application.Avalanche) {},
+		},
+		{
+			name: "malformed run file",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) {
+				writeTestRunFile(require, app, []byte("{not json"))
+			},
+		},
+		{
+			name: "missing pid",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) {
+				writeTestRunFileWithPid(require, app, getExitedPid(require))
+			},
+		},
+		{
+			name: "non backend pid",
+			setup: func(_ *testing.T, require *require.Assertions, app *application.Avalanche) {
+				writeTestRunFileWithPid(require, app, os.Getpid())
+			},
+		},
+		{
+			name: "backend pid",
+			setup: func(t *testing.T, require *require.Assertions, app *application.Avalanche) {
+				writeTestRunFileWithPid(require, app, startFakeBackend(t, require))
+			},
+			expectedRunning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := testutils.SetupTest(t)
+			app := newBackendTestApp(t)
+			tt.setup(t, require, app)
+
+			running, err := NewProcessChecker().IsServerProcessRunning(app)
+			require.NoError(err)
+			require.Equal(tt.expectedRunning, running)
+
+			// stale run files are removed, the one of a running backend is kept
+			_, err = os.Stat(app.GetRunFile())
+			if tt.expectedRunning {
+				require.NoError(err)
+			} else {
+				require.True(os.IsNotExist(err))
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrNoReleaseChecksums is returned when installing a binary whose release publishes
+	// no checksums, if checksums are required
+	ErrNoReleaseChecksums = errors.New("release publishes no checksums")
+)
+
+// installChecksums is recorded next to an installed binary, so that it
+// can be re-verified on disk
+type installChecksums struct {
+	Archive       string `json:"archive"`
+	ArchiveSHA256 string `json:"archiveSHA256"`
+	// the archive hash was checked against the checksums file of the release
+	ArchiveVerified bool `json:"archiveVerified"`
+	// sha256 of the installed files, by path relative to the install dir
+	Files map[string]string `json:"files"`
+}
+
+// verifies [archive], downloaded from [archiveURL], against the checksums file of
+// release [version], and returns its sha256 and whether it was verified. Releases
+// publishing no checksums file are not verified, or refused if checksums are required
+func verifyArchive(
+	app *application.Avalanche,
+	archive []byte,
+	archiveURL string,
+	version string,
+	downloader GithubDownloader,
+) (string, bool, error) {
+	archiveName := path.Base(archiveURL)
+	archiveSum := utils.GetSHA256(archive)
+	checksumsURL := downloader.GetChecksumsURL(version)
+	if checksumsURL == "" {
+		if app.RequireChecksums {
+			return "", false, fmt.Errorf("%w: %s of release %s can't be verified", ErrNoReleaseChecksums, archiveName, version)
+		}
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Release %s publishes no checksums file, %s can't be verified", version, archiveName)))
+		return archiveSum, false, nil
+	}
+	app.Log.Debug("downloading release checksums", zap.String("checksums-url", checksumsURL))
+	checksums, err := app.Downloader.Download(checksumsURL)
+	if err != nil {
+		return "", false, fmt.Errorf("unable to download release checksums: %w", err)
+	}
+	expectedSum, err := utils.SearchSHA256File(checksums, archiveName)
+	if err != nil {
+		return "", false, fmt.Errorf("failed obtaining archive checksum: %w", err)
+	}
+	if archiveSum != expectedSum {
+		return "", false, fmt.Errorf("%w: %s has sha256 %s but release %s expects %s",
+			ErrChecksumMismatch, archiveName, archiveSum, version, expectedSum)
+	}
+	return archiveSum, true, nil
+}
+
+// records the hashes of the archive and of the files installed from it into [binDir]
+func writeInstallChecksums(binDir string, archiveName string, archiveSum string, verified bool) error {
+	checksums := installChecksums{
+		Archive:         archiveName,
+		ArchiveSHA256:   archiveSum,
+		ArchiveVerified: verified,
+		Files:           map[string]string{},
+	}
+	err := filepath.WalkDir(binDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() || filePath == getChecksumsPath(binDir) {
+			return nil
+		}
+		relPath, err := filepath.Rel(binDir, filePath)
+		if err != nil {
+			return err
+		}
+		fileSum, err := utils.GetSHA256FromDisk(filePath)
+		if err != nil {
+			return err
+		}
+		checksums.Files[relPath] = fileSum
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed hashing installed files: %w", err)
+	}
+	checksumsBytes, err := json.MarshalIndent(checksums, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(getChecksumsPath(binDir), checksumsBytes, perms.ReadWrite)
+}
+
+// VerifyInstalledBinary checks the files installed into [binDir] still match the
+// hashes recorded at install time. Installs with no recorded hashes are not checked
+func VerifyInstalledBinary(app *application.Avalanche, binDir string) error {
+	checksumsBytes, err := os.ReadFile(getChecksumsPath(binDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			app.Log.Debug("no checksums recorded for installed binary", zap.String("dir", binDir))
+			return nil
+		}
+		return err
+	}
+	var checksums installChecksums
+	if err := json.Unmarshal(checksumsBytes, &checksums); err != nil {
+		return fmt.Errorf("failed reading checksums of %s: %w", binDir, err)
+	}
+	for relPath, expectedSum := range checksums.Files {
+		fileSum, err := utils.GetSHA256FromDisk(filepath.Join(binDir, relPath))
+		if err != nil {
+			return err
+		}
+		if fileSum != expectedSum {
+			return fmt.Errorf("%w: %s has sha256 %s but was installed with %s. Remove %s to reinstall it",
+				ErrChecksumMismatch, filepath.Join(binDir, relPath), fileSum, expectedSum, binDir)
+		}
+	}
+	return nil
+}
+
+func getChecksumsPath(binDir string) string {
+	return filepath.Join(binDir, constants.BinaryChecksumsFileName)
+}
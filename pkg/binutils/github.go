@@ -20,6 +20,9 @@ const (
 
 type GithubDownloader interface {
 	GetDownloadURL(version string, installer Installer) (string, string, error)
+	// GetChecksumsURL returns the URL of the sha256 checksums file of the release
+	// archives, or an empty string if the release publishes none
+	GetChecksumsURL(version string) string
 }
 
 type (
@@ -86,6 +89,11 @@ func (avalancheGoDownloader) GetDownloadURL(version string, installer Installer)
 	return avalanchegoURL, ext, nil
 }
 
+// avalanchego releases publish no checksums file
+func (avalancheGoDownloader) GetChecksumsURL(string) string {
+	return ""
+}
+
 func NewSubnetEVMDownloader() GithubDownloader {
 	return &subnetEVMDownloader{}
 }
@@ -125,6 +133,10 @@ func (subnetEVMDownloader) GetDownloadURL(version string, installer Installer) (
 	return subnetEVMURL, ext, nil
 }
 
+func (subnetEVMDownloader) GetChecksumsURL(version string) string {
+	return getGoreleaserChecksumsURL(constants.SubnetEVMRepoName, version)
+}
+
 func NewSpacesVMDownloader() GithubDownloader {
 	return &spacesVMDownloader{}
 }
@@ -163,3 +175,20 @@ func (spacesVMDownloader) GetDownloadURL(version string, installer Installer) (s
 
 	return spacesVMURL, ext, nil
 }
+
+func (spacesVMDownloader) GetChecksumsURL(version string) string {
+	return getGoreleaserChecksumsURL(constants.SpacesVMRepoName, version)
+}
+
+// returns the URL of the checksums file goreleaser publishes along the archives
+// of release [version] of [repo]
+func getGoreleaserChecksumsURL(repo string, version string) string {
+	return fmt.Sprintf(
+		"https://github.com/%s/%s/releases/download/%s/%s_%s_checksums.txt",
+		constants.AvaLabsOrg,
+		repo,
+		version,
+		repo,
+		version[1:],
+	)
+}
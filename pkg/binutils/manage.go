@@ -0,0 +1,87 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+)
+
+var (
+	ErrUnknownBinary      = errors.New("unknown binary")
+	ErrBinaryNotInstalled = errors.New("binary version is not installed")
+)
+
+// GetBinaryNames returns the names of the binaries installed under the bin dir
+func GetBinaryNames(app *application.Avalanche) []string {
+	names := []string{}
+	for _, kind := range getBinaryKinds(app) {
+		names = append(names, kind.name)
+	}
+	return names
+}
+
+func getBinaryKind(app *application.Avalanche, name string) (binaryKind, error) {
+	for _, kind := range getBinaryKinds(app) {
+		if kind.name == name {
+			return kind, nil
+		}
+	}
+	return binaryKind{}, fmt.Errorf("%w %q, expected one of %s", ErrUnknownBinary, name,
+		strings.Join(GetBinaryNames(app), ", "))
+}
+
+// GetInstalledBinary returns the installed [version] of binary [name]
+func GetInstalledBinary(app *application.Avalanche, name string, version string) (InstalledBinary, error) {
+	if _, err := getBinaryKind(app, name); err != nil {
+		return InstalledBinary{}, err
+	}
+	installed, err := GetInstalledBinaries(app)
+	if err != nil {
+		return InstalledBinary{}, err
+	}
+	for _, binary := range installed {
+		if binary.Name == name && binary.Version == version {
+			return binary, nil
+		}
+	}
+	return InstalledBinary{}, fmt.Errorf("%w: %s %s", ErrBinaryNotInstalled, name, version)
+}
+
+// GetBinaryExecPath returns the path of the executable of [binary]
+func GetBinaryExecPath(app *application.Avalanche, binary InstalledBinary) (string, error) {
+	kind, err := getBinaryKind(app, binary.Name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(binary.Path, kind.bin), nil
+}
+
+// InstallBinaryVersion installs [version] of binary [name], or its latest release
+// if [version] is "latest", and returns the installed version
+func InstallBinaryVersion(app *application.Avalanche, name string, version string) (string, error) {
+	kind, err := getBinaryKind(app, name)
+	if err != nil {
+		return "", err
+	}
+	if version == "latest" {
+		version, err = app.Downloader.GetLatestReleaseVersion(GetGithubLatestReleaseURL(constants.AvaLabsOrg, kind.repo))
+		if err != nil {
+			return "", err
+		}
+	}
+	switch name {
+	case constants.AvalancheGoInstallDir:
+		_, err = SetupAvalanchego(app, version)
+	case constants.SubnetEVMInstallDir:
+		_, err = SetupSubnetEVM(app, version)
+	case constants.SpacesVMInstallDir:
+		_, err = SetupSpacesVM(app, version)
+	}
+	return version, err
+}
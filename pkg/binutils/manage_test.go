@@ -0,0 +1,68 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryReferences(t *testing.T) {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, &config.Config{}, prompts.NewPrompter(), application.NewDownloader())
+	require.NoError(os.MkdirAll(app.GetSubnetDir(), constants.DefaultPerms755))
+	// installed avalanchego given by path on a local deploy
+	localAvagoDir := filepath.Join(app.GetAvalanchegoBinDir(), avalanchegoBinPrefix+"v1.19.1")
+
+	for _, dir := range []string{
+		filepath.Join(app.GetAvalanchegoBinDir(), avalanchegoBinPrefix+version1),
+		localAvagoDir,
+		filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1),
+		filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version2),
+	} {
+		require.NoError(os.MkdirAll(dir, constants.DefaultPerms755))
+	}
+	require.NoError(app.CreateSidecar(&models.Sidecar{
+		Name:      "subnet1",
+		VM:        models.SubnetEvm,
+		VMVersion: version2,
+		Networks: map[string]models.NetworkData{
+			app.GetNetworkKey(models.Local): {VMVersion: version2, PreviousVMVersion: version1},
+		},
+	}))
+	require.NoError(app.CreateSidecar(&models.Sidecar{
+		Name:            "subnet2",
+		VM:              models.CustomVM,
+		AvalancheGoPath: filepath.Join(localAvagoDir, constants.AvalancheGoBin),
+	}))
+
+	references, err := GetBinaryReferences(app)
+	require.NoError(err)
+	require.Equal([]string{"subnet subnet1", "subnet subnet1 (Local Network (default))"},
+		references[filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version2)])
+	require.Equal([]string{"subnet subnet1 (Local Network (default) rollback)"},
+		references[filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1)])
+	require.NotContains(references, filepath.Join(app.GetAvalanchegoBinDir(), avalanchegoBinPrefix+version1))
+	require.Equal([]string{"subnet subnet2 (local deploy binary)"}, references[localAvagoDir])
+
+	binary, err := GetInstalledBinary(app, constants.AvalancheGoInstallDir, version1)
+	require.NoError(err)
+	execPath, err := GetBinaryExecPath(app, binary)
+	require.NoError(err)
+	require.Equal(filepath.Join(app.GetAvalanchegoBinDir(), avalanchegoBinPrefix+version1, constants.AvalancheGoBin), execPath)
+
+	_, err = GetInstalledBinary(app, constants.AvalancheGoInstallDir, version2)
+	require.ErrorIs(err, ErrBinaryNotInstalled)
+	_, err = GetInstalledBinary(app, "coreth", version1)
+	require.ErrorIs(err, ErrUnknownBinary)
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"golang.org/x/mod/semver"
+)
+
+// installer of a given platform, to get the release archives of other platforms
+type platformInstaller struct {
+	goos   string
+	goarch string
+}
+
+func (i platformInstaller) GetArch() (string, string) {
+	return i.goarch, i.goos
+}
+
+// MirrorReleases populates the release mirror at [mirrorDir] with the archives of
+// [versions] of each binary, by binary name, for [platforms] given as os/arch, along
+// with their release checksums, the release lists, the compatibility data and the
+// bootstrap snapshot, all downloaded from GitHub by [upstream]
+func MirrorReleases(
+	app *application.Avalanche,
+	upstream application.Downloader,
+	mirrorDir string,
+	versions map[string][]string,
+	platforms []string,
+) error {
+	installers := []Installer{}
+	for _, platform := range platforms {
+		goos, goarch, ok := strings.Cut(platform, "/")
+		if !ok || goos == "" || goarch == "" {
+			return fmt.Errorf("invalid platform %q, expected os/arch (ex: linux/amd64)", platform)
+		}
+		installers = append(installers, platformInstaller{goos: goos, goarch: goarch})
+	}
+
+	for _, kind := range getBinaryKinds(app) {
+		downloader, err := getGithubDownloader(kind.name)
+		if err != nil {
+			return err
+		}
+		mirrored := []string{}
+		for _, version := range versions[kind.name] {
+			if version == "latest" {
+				version, err = upstream.GetLatestReleaseVersion(GetGithubLatestReleaseURL(constants.AvaLabsOrg, kind.repo))
+				if err != nil {
+					return err
+				}
+			} else if !semver.IsValid(version) {
+				return fmt.Errorf("invalid %s version %q, must be a semantic version (ex: v1.9.4)", kind.name, version)
+			}
+			urls := []string{}
+			if checksumsURL := downloader.GetChecksumsURL(version); checksumsURL != "" {
+				urls = append(urls, checksumsURL)
+			}
+			for _, installer := range installers {
+				archiveURL, _, err := downloader.GetDownloadURL(version, installer)
+				if err != nil {
+					return err
+				}
+				urls = append(urls, archiveURL)
+			}
+			ux.Logger.PrintToUser("Mirroring %s %s...", kind.name, version)
+			if err := mirrorURLs(upstream, mirrorDir, urls); err != nil {
+				return err
+			}
+			mirrored = append(mirrored, version)
+		}
+		if len(mirrored) > 0 {
+			if err := updateMirrorReleases(mirrorDir, kind.repo, mirrored); err != nil {
+				return err
+			}
+		}
+	}
+
+	ux.Logger.PrintToUser("Mirroring compatibility data and bootstrap snapshot...")
+	return mirrorURLs(upstream, mirrorDir, []string{
+		constants.AvalancheGoCompatibilityURL,
+		constants.SubnetEVMRPCCompatibilityURL,
+		constants.SpacesVMRPCCompatibilityURL,
+		constants.BootstrapSnapshotURL,
+		constants.BootstrapSnapshotSHA256URL,
+	})
+}
+
+func getGithubDownloader(name string) (GithubDownloader, error) {
+	switch name {
+	case constants.AvalancheGoInstallDir:
+		return NewAvagoDownloader(), nil
+	case constants.SubnetEVMInstallDir:
+		return NewSubnetEVMDownloader(), nil
+	case constants.SpacesVMInstallDir:
+		return NewSpacesVMDownloader(), nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownBinary, name)
+}
+
+// downloads each of [urls], skipping duplicates, into its path at the mirror
+func mirrorURLs(upstream application.Downloader, mirrorDir string, urls []string) error {
+	done := map[string]bool{}
+	for _, url := range urls {
+		if done[url] {
+			continue
+		}
+		done[url] = true
+		b, err := upstream.Download(url)
+		if err != nil {
+			return fmt.Errorf("failed downloading %s: %w", url, err)
+		}
+		if err := writeMirrorFile(mirrorDir, url, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adds [versions] to the release lists of [repo] at the mirror, as served by the
+// GitHub API, and sets the latest release to the highest mirrored version
+func updateMirrorReleases(mirrorDir string, repo string, versions []string) error {
+	releasesURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", constants.AvaLabsOrg, repo)
+	releasesPath, err := getMirrorFilePath(mirrorDir, releasesURL)
+	if err != nil {
+		return err
+	}
+	all := map[string]bool{}
+	for _, version := range versions {
+		all[version] = true
+	}
+	if releasesBytes, err := os.ReadFile(releasesPath); err == nil {
+		var releases []map[string]string
+		if err := json.Unmarshal(releasesBytes, &releases); err != nil {
+			return fmt.Errorf("failed reading mirrored releases %s: %w", releasesPath, err)
+		}
+		for _, release := range releases {
+			all[release["tag_name"]] = true
+		}
+	}
+	sorted := []string{}
+	for version := range all {
+		sorted = append(sorted, version)
+	}
+	// latest first, as GitHub lists them
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return semver.Compare(sorted[i], sorted[j]) > 0
+	})
+	releases := []map[string]string{}
+	for _, version := range sorted {
+		releases = append(releases, map[string]string{"tag_name": version})
+	}
+	releasesBytes, err := json.MarshalIndent(releases, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeMirrorFile(mirrorDir, releasesURL, releasesBytes); err != nil {
+		return err
+	}
+	latestBytes, err := json.MarshalIndent(releases[0], "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeMirrorFile(mirrorDir, GetGithubLatestReleaseURL(constants.AvaLabsOrg, repo), latestBytes)
+}
+
+func getMirrorFilePath(mirrorDir string, url string) (string, error) {
+	mirrorPath, err := application.GetMirrorPath(url)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(mirrorDir, filepath.FromSlash(mirrorPath)), nil
+}
+
+func writeMirrorFile(mirrorDir string, url string, b []byte) error {
+	filePath, err := getMirrorFilePath(mirrorDir, url)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, b, perms.ReadWrite)
+}
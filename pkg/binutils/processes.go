@@ -11,7 +11,10 @@ import (
 	"os/exec"
 	"os/signal"
 	"path"
+	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
@@ -19,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanche-network-runner/client"
 	"github.com/ava-labs/avalanche-network-runner/server"
 	"github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/docker/docker/pkg/reexec"
@@ -26,8 +30,14 @@ import (
 	"go.uber.org/zap"
 )
 
-// ErrGRPCTimeout is a common error message if the gRPC server can't be reached
-var ErrGRPCTimeout = errors.New("timed out trying to contact backend controller, it is most probably not running")
+var (
+	// ErrGRPCTimeout is a common error message if the gRPC server can't be reached
+	ErrGRPCTimeout = errors.New("timed out trying to contact backend controller, it is most probably not running")
+	// ErrNodeProcessNotFound is returned if the process of a local network node can't be found
+	ErrNodeProcessNotFound = errors.New("node process not found")
+	// ErrBackendNotRunning is returned if the run file doesn't point to a running backend
+	ErrBackendNotRunning = errors.New("backend controller is not running")
+)
 
 // ProcessChecker is responsible for checking if the gRPC server is running
 type ProcessChecker interface {
@@ -44,7 +54,12 @@ func NewProcessChecker() ProcessChecker {
 }
 
 // NewGRPCClient hides away the details (params) of creating a gRPC server connection
-func NewGRPCClient() (client.Client, error) {
+// to the backend of the local network targeted by [app]
+func NewGRPCClient(app *application.Avalanche) (client.Client, error) {
+	localNetwork, err := app.LoadLocalNetwork()
+	if err != nil {
+		return nil, err
+	}
 	logLevel, err := logging.ToLevel(gRPCClientLogLevel)
 	if err != nil {
 		return nil, err
@@ -58,7 +73,7 @@ func NewGRPCClient() (client.Client, error) {
 		return nil, err
 	}
 	client, err := client.New(client.Config{
-		Endpoint:    gRPCServerEndpoint,
+		Endpoint:    localNetwork.GRPCServerEndpoint(),
 		DialTimeout: gRPCDialTimeout,
 	}, log)
 	if errors.Is(err, context.DeadlineExceeded) {
@@ -67,8 +82,13 @@ func NewGRPCClient() (client.Client, error) {
 	return client, err
 }
 
-// NewGRPCClient hides away the details (params) of creating a gRPC server
-func NewGRPCServer(snapshotsDir string) (server.Server, error) {
+// NewGRPCServer hides away the details (params) of creating a gRPC server
+// for the local network targeted by [app]
+func NewGRPCServer(app *application.Avalanche) (server.Server, error) {
+	localNetwork, err := app.LoadLocalNetwork()
+	if err != nil {
+		return nil, err
+	}
 	logFactory := logging.NewFactory(logging.Config{
 		DisplayLevel: logging.Info,
 		LogLevel:     logging.Off,
@@ -78,69 +98,131 @@ func NewGRPCServer(snapshotsDir string) (server.Server, error) {
 		return nil, err
 	}
 	return server.New(server.Config{
-		Port:                gRPCServerEndpoint,
-		GwPort:              gRPCGatewayEndpoint,
+		Port:                localNetwork.GRPCServerEndpoint(),
+		GwPort:              localNetwork.GRPCGatewayEndpoint(),
 		DialTimeout:         gRPCDialTimeout,
-		SnapshotsDir:        snapshotsDir,
+		SnapshotsDir:        app.GetSnapshotsDir(),
 		RedirectNodesOutput: false,
 	}, log)
 }
 
 // IsServerProcessRunning returns true if the gRPC server is running,
-// or false if not
+// or false if not. A run file left by a backend that is no longer
+// running is removed
 func (*realProcessRunner) IsServerProcessRunning(app *application.Avalanche) (bool, error) {
-	pid, err := GetServerPID(app)
-	if err != nil {
-		if !errors.Is(err, os.ErrNotExist) {
+	if _, err := os.Stat(app.GetRunFile()); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if _, err := GetServerProcess(app); err != nil {
+		if !errors.Is(err, ErrBackendNotRunning) {
+			return false, err
+		}
+		app.Log.Debug("removing stale backend run file", zap.Error(err))
+		if err := os.Remove(app.GetRunFile()); err != nil && !os.IsNotExist(err) {
 			return false, err
 		}
 		return false, nil
 	}
+	return true, nil
+}
 
-	// get OS process list
+// GetNodeProcess returns the process of the local network node that logs to [logDir].
+// The network runner gives each node its own log dir on the command line
+func GetNodeProcess(logDir string) (*process.Process, error) {
+	if logDir == "" {
+		return nil, ErrNodeProcessNotFound
+	}
 	procs, err := process.Processes()
 	if err != nil {
-		return false, err
+		return nil, err
 	}
-
-	p32 := int32(pid)
-	// iterate all processes...
 	for _, p := range procs {
-		if p.Pid == p32 {
-			return true, nil
+		exe, err := p.Exe()
+		if err != nil {
+			// ignore processes of other users, or that just died
+			continue
+		}
+		args, err := p.CmdlineSlice()
+		if err != nil {
+			// ignore errors for processes that just died (macos implementation)
+			continue
+		}
+		if isNodeProcess(exe, args, logDir) {
+			return p, nil
 		}
 	}
-	return false, nil
+	return nil, fmt.Errorf("%w: no process logging to %s", ErrNodeProcessNotFound, logDir)
+}
+
+// returns true if the process of executable [exe] and command line [args] is an
+// avalanchego given [logDir] as log dir, as opposed to other processes using that
+// dir, as a tail of its logs
+func isNodeProcess(exe string, args []string, logDir string) bool {
+	if !strings.Contains(filepath.Base(exe), constants.AvalancheGoRepoName) {
+		return false
+	}
+	logDirArg := "--" + config.LogsDirKey + "=" + logDir
+	for _, arg := range args {
+		if arg == logDirArg {
+			return true
+		}
+	}
+	return false
 }
 
 type runFile struct {
 	Pid                int    `json:"pid"`
 	GRPCserverFileName string `json:"gRPCserverFileName"`
+	// version of the CLI that started the backend, empty for older CLIs
+	Version   string    `json:"version"`
+	StartedAt time.Time `json:"startedAt"`
 }
 
-func GetServerPID(app *application.Avalanche) (int, error) {
+func readRunFile(app *application.Avalanche) (runFile, error) {
 	var rf runFile
 	serverRunFilePath := app.GetRunFile()
 	run, err := os.ReadFile(serverRunFilePath)
 	if err != nil {
-		return 0, fmt.Errorf("failed reading process info file at %s: %w", serverRunFilePath, err)
+		return rf, fmt.Errorf("failed reading process info file at %s: %w", serverRunFilePath, err)
 	}
 	if err := json.Unmarshal(run, &rf); err != nil {
-		return 0, fmt.Errorf("failed unmarshalling server run file at %s: %w", serverRunFilePath, err)
+		return rf, fmt.Errorf("failed unmarshalling server run file at %s: %w", serverRunFilePath, err)
 	}
+	return rf, nil
+}
 
+func GetServerPID(app *application.Avalanche) (int, error) {
+	rf, err := readRunFile(app)
+	if err != nil {
+		return 0, err
+	}
 	if rf.Pid == 0 {
-		return 0, fmt.Errorf("failed reading pid from info file at %s: %w", serverRunFilePath, err)
+		return 0, fmt.Errorf("failed reading pid from info file at %s", app.GetRunFile())
 	}
 	return rf.Pid, nil
 }
 
+// GetServerOutputPath returns the file the gRPC server output is redirected to
+func GetServerOutputPath(app *application.Avalanche) (string, error) {
+	rf, err := readRunFile(app)
+	if err != nil {
+		return "", err
+	}
+	if rf.GRPCserverFileName == "" {
+		return "", fmt.Errorf("failed reading server output file from info file at %s", app.GetRunFile())
+	}
+	return rf.GRPCserverFileName, nil
+}
+
 // StartServerProcess starts the gRPC server as a reentrant process of this binary
-// it just executes `avalanche-cli backend start`
+// it just executes `avalanche-cli backend start`, for the local network targeted by [app]
 func StartServerProcess(app *application.Avalanche) error {
 	thisBin := reexec.Self()
 
-	args := []string{constants.BackendCmd}
+	args := []string{constants.BackendCmd, "--" + constants.LocalNetworkBackendFlag, app.GetLocalNetworkName()}
 	cmd := exec.Command(thisBin, args...)
 
 	outputDirPrefix := path.Join(app.GetRunDir(), "server")
@@ -166,6 +248,8 @@ func StartServerProcess(app *application.Avalanche) error {
 	rf := runFile{
 		Pid:                cmd.Process.Pid,
 		GRPCserverFileName: outputFile.Name(),
+		Version:            GetCLIVersion(app),
+		StartedAt:          time.Now(),
 	}
 
 	rfBytes, err := json.Marshal(&rf)
@@ -193,7 +277,14 @@ func GetAsyncContext() context.Context {
 }
 
 func KillgRPCServerProcess(app *application.Avalanche) error {
-	cli, err := NewGRPCClient()
+	if _, err := GetServerProcess(app); err != nil {
+		if errors.Is(err, ErrBackendNotRunning) {
+			// stale run file
+			_ = os.Remove(app.GetRunFile())
+		}
+		return err
+	}
+	cli, err := NewGRPCClient(app)
 	if err != nil {
 		return err
 	}
@@ -208,23 +299,7 @@ func KillgRPCServerProcess(app *application.Avalanche) error {
 		}
 	}
 
-	pid, err := GetServerPID(app)
-	if err != nil {
-		return fmt.Errorf("failed getting PID from run file: %w", err)
-	}
-	proc, err := os.FindProcess(pid)
-	if err != nil {
-		return fmt.Errorf("could not find process with pid %d: %w", pid, err)
-	}
-	if err := proc.Signal(os.Interrupt); err != nil {
-		return fmt.Errorf("failed killing process with pid %d: %w", pid, err)
-	}
-
-	serverRunFilePath := app.GetRunFile()
-	if err := os.Remove(serverRunFilePath); err != nil {
-		return fmt.Errorf("failed removing run file %s: %w", serverRunFilePath, err)
-	}
-	return nil
+	return StopServerProcess(app)
 }
 
 func WatchServerProcess(serverCancel context.CancelFunc, errc chan error, log logging.Logger) {
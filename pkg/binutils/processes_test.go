@@ -0,0 +1,48 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package binutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsNodeProcess(t *testing.T) {
+	const logDir = "/home/user/.avalanche-cli/runs/network_1/node1/logs"
+
+	tests := []struct {
+		name     string
+		exe      string
+		args     []string
+		expected bool
+	}{
+		{
+			name:     "node",
+			exe:      "/home/user/.avalanche-cli/bin/avalanchego/avalanchego-v1.9.4/avalanchego",
+			args:     []string{"avalanchego", "--http-port=9650", "--log-dir=" + logDir},
+			expected: true,
+		},
+		{
+			name: "node of another log dir",
+			exe:  "/home/user/.avalanche-cli/bin/avalanchego/avalanchego-v1.9.4/avalanchego",
+			args: []string{"avalanchego", "--log-dir=" + logDir + "2"},
+		},
+		{
+			name: "tail of the node logs",
+			exe:  "/usr/bin/tail",
+			args: []string{"tail", "-f", logDir + "/main.log"},
+		},
+		{
+			name: "avalanchego log dir passed to another binary",
+			exe:  "/usr/bin/vim",
+			args: []string{"vim", "--log-dir=" + logDir},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isNodeProcess(tt.exe, tt.args, logDir))
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/shirou/gopsutil/process"
+	"go.uber.org/zap"
+)
+
+// InstalledBinary is a version of avalanchego or of a VM installed under the bin dir
+type InstalledBinary struct {
+	// one of constants.AvalancheGoInstallDir, SubnetEVMInstallDir or SpacesVMInstallDir
+	Name    string
+	Version string
+	// install dir of this version
+	Path string
+	Size int64
+}
+
+type binaryKind struct {
+	name   string
+	binDir string
+	prefix string
+	repo   string
+	// name of the executable inside the install dir of a version
+	bin string
+}
+
+func getBinaryKinds(app *application.Avalanche) []binaryKind {
+	return []binaryKind{
+		{constants.AvalancheGoInstallDir, app.GetAvalanchegoBinDir(), avalanchegoBinPrefix, constants.AvalancheGoRepoName, constants.AvalancheGoBin},
+		{constants.SubnetEVMInstallDir, app.GetSubnetEVMBinDir(), subnetEVMBinPrefix, constants.SubnetEVMRepoName, constants.SubnetEVMBin},
+		{constants.SpacesVMInstallDir, app.GetSpacesVMBinDir(), spacesVMBinPrefix, constants.SpacesVMRepoName, constants.SpacesVMBin},
+	}
+}
+
+// GetInstalledBinaries returns all the versions of avalanchego and of the VMs
+// installed under the bin dir, sorted by name and version
+func GetInstalledBinaries(app *application.Avalanche) ([]InstalledBinary, error) {
+	binaries := []InstalledBinary{}
+	for _, kind := range getBinaryKinds(app) {
+		entries, err := os.ReadDir(kind.binDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() || !strings.HasPrefix(entry.Name(), kind.prefix) {
+				continue
+			}
+			path := filepath.Join(kind.binDir, entry.Name())
+			size, err := utils.DirSize(path)
+			if err != nil {
+				return nil, err
+			}
+			binaries = append(binaries, InstalledBinary{
+				Name:    kind.name,
+				Version: strings.TrimPrefix(entry.Name(), kind.prefix),
+				Path:    path,
+				Size:    size,
+			})
+		}
+	}
+	sort.SliceStable(binaries, func(i, j int) bool {
+		if binaries[i].Name != binaries[j].Name {
+			return binaries[i].Name < binaries[j].Name
+		}
+		return binaries[i].Version < binaries[j].Version
+	})
+	return binaries, nil
+}
+
+// GetBinaryReferences returns, by install dir, what references the installed versions
+// of avalanchego and of the VMs: the sidecars of the subnets using them, including the
+// versions kept for rollbacks and the binaries given on local deploys, and the processes
+// running them, as the nodes of the local network
+func GetBinaryReferences(app *application.Avalanche) (map[string][]string, error) {
+	references := map[string][]string{}
+	addReference := func(path string, reference string) {
+		for _, r := range references[path] {
+			if r == reference {
+				return
+			}
+		}
+		references[path] = append(references[path], reference)
+	}
+
+	// binaries given by path on local deploys, by referencing subnet
+	localBinaries := map[string][]string{}
+	sidecarNames, err := app.GetSidecarNames()
+	if err != nil {
+		return nil, err
+	}
+	for _, sidecarName := range sidecarNames {
+		sc, err := app.LoadSidecar(sidecarName)
+		if err != nil {
+			app.Log.Debug("failed loading sidecar", zap.String("subnet", sidecarName), zap.Error(err))
+			continue
+		}
+		for _, localBinary := range []string{sc.VMPath, sc.AvalancheGoPath} {
+			if localBinary != "" {
+				localBinaries[localBinary] = append(localBinaries[localBinary], sc.Name)
+			}
+		}
+		var binDir, prefix string
+		switch sc.VM {
+		case models.SubnetEvm:
+			binDir, prefix = app.GetSubnetEVMBinDir(), subnetEVMBinPrefix
+		case models.SpacesVM:
+			binDir, prefix = app.GetSpacesVMBinDir(), spacesVMBinPrefix
+		default:
+			continue
+		}
+		if sc.VMVersion != "" {
+			addReference(filepath.Join(binDir, prefix+sc.VMVersion), "subnet "+sc.Name)
+		}
+		for networkName, network := range sc.Networks {
+			if network.VMVersion != "" {
+				addReference(filepath.Join(binDir, prefix+network.VMVersion),
+					fmt.Sprintf("subnet %s (%s)", sc.Name, networkName))
+			}
+			if network.PreviousVMVersion != "" {
+				addReference(filepath.Join(binDir, prefix+network.PreviousVMVersion),
+					fmt.Sprintf("subnet %s (%s rollback)", sc.Name, networkName))
+			}
+		}
+	}
+
+	installed, err := GetInstalledBinaries(app)
+	if err != nil {
+		return nil, err
+	}
+	for localBinary, subnetNames := range localBinaries {
+		for _, binary := range installed {
+			if !strings.HasPrefix(localBinary, binary.Path+string(filepath.Separator)) {
+				continue
+			}
+			for _, subnetName := range subnetNames {
+				addReference(binary.Path, fmt.Sprintf("subnet %s (local deploy binary)", subnetName))
+			}
+		}
+	}
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	runDir := app.GetRunDir() + string(filepath.Separator)
+	for _, p := range procs {
+		exe, err := p.Exe()
+		if err != nil {
+			// ignore processes of other users, or that just died
+			continue
+		}
+		for _, binary := range installed {
+			if !strings.HasPrefix(exe, binary.Path+string(filepath.Separator)) {
+				continue
+			}
+			if cmdline, err := p.Cmdline(); err == nil && strings.Contains(cmdline, runDir) {
+				addReference(binary.Path, "local network "+app.GetLocalNetworkName())
+			} else {
+				addReference(binary.Path, fmt.Sprintf("process %d", p.Pid))
+			}
+		}
+	}
+	for _, pathReferences := range references {
+		sort.Strings(pathReferences)
+	}
+	return references, nil
+}
+
+// GetUsedBinaryPaths returns the install dirs of the binaries with references,
+// as given by GetBinaryReferences
+func GetUsedBinaryPaths(app *application.Avalanche) (map[string]bool, error) {
+	references, err := GetBinaryReferences(app)
+	if err != nil {
+		return nil, err
+	}
+	used := map[string]bool{}
+	for path := range references {
+		used[path] = true
+	}
+	return used, nil
+}
+
+// PruneBinaries removes the installed versions of avalanchego and of the VMs
+// that are not in use, as given by GetUsedBinaryPaths, and returns them
+func PruneBinaries(app *application.Avalanche) ([]InstalledBinary, error) {
+	used, err := GetUsedBinaryPaths(app)
+	if err != nil {
+		return nil, err
+	}
+	installed, err := GetInstalledBinaries(app)
+	if err != nil {
+		return nil, err
+	}
+	return pruneBinaries(installed, used)
+}
+
+func pruneBinaries(installed []InstalledBinary, used map[string]bool) ([]InstalledBinary, error) {
+	removed := []InstalledBinary{}
+	for _, binary := range installed {
+		if used[binary.Path] {
+			continue
+		}
+		if err := os.RemoveAll(binary.Path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, binary)
+	}
+	return removed, nil
+}
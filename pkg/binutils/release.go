@@ -5,6 +5,7 @@ package binutils
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -35,7 +36,13 @@ func installBinaryWithVersion(
 		return "", fmt.Errorf("unable to download binary: %w", err)
 	}
 
-	app.Log.Debug("download successful. installing archive...")
+	app.Log.Debug("download successful. verifying archive checksum...")
+	archiveSum, verified, err := verifyArchive(app, archive, installURL, version, downloader)
+	if err != nil {
+		return "", err
+	}
+
+	app.Log.Debug("installing archive...")
 	if err := InstallArchive(ext, archive, binDir); err != nil {
 		return "", err
 	}
@@ -46,13 +53,17 @@ func installBinaryWithVersion(
 			return "", err
 		}
 	}
-	ux.Logger.PrintToUser(binPrefix + version + " installation successful")
 
+	installedDir := binDir
 	if !strings.Contains(binDir, version) {
-		return filepath.Join(binDir, binPrefix+version), nil
+		installedDir = filepath.Join(binDir, binPrefix+version)
 	}
+	if err := writeInstallChecksums(installedDir, path.Base(installURL), archiveSum, verified); err != nil {
+		return "", err
+	}
+	ux.Logger.PrintToUser(binPrefix + version + " installation successful")
 
-	return binDir, nil
+	return installedDir, nil
 }
 
 func InstallBinary(
@@ -89,7 +100,11 @@ func InstallBinary(
 	}
 	if exists {
 		app.Log.Debug(binPrefix + version + " found. Skipping installation")
-		return filepath.Join(baseBinDir, binPrefix+version), nil
+		binDir := filepath.Join(baseBinDir, binPrefix+version)
+		if err := VerifyInstalledBinary(app, binDir); err != nil {
+			return "", err
+		}
+		return binDir, nil
 	}
 
 	app.Log.Info("Using binary version", zap.String("version", version))
@@ -4,7 +4,9 @@
 package binutils
 
 import (
+	"encoding/json"
 	"os"
+	"path"
 	"path/filepath"
 	"testing"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/config"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -94,6 +97,13 @@ func Test_installAvalancheGoWithVersion_Tar(t *testing.T) {
 	installedBin, err := os.ReadFile(filepath.Join(binDir, avalanchegoBin))
 	require.NoError(err)
 	require.Equal(binary1, installedBin)
+
+	// installed without verification, as avalanchego releases publish no checksums
+	checksumsBytes, err := os.ReadFile(getChecksumsPath(binDir))
+	require.NoError(err)
+	var checksums installChecksums
+	require.NoError(json.Unmarshal(checksumsBytes, &checksums))
+	require.False(checksums.ArchiveVerified)
 }
 
 func Test_installAvalancheGoWithVersion_MultipleCoinstalls(t *testing.T) {
@@ -142,6 +152,28 @@ func Test_installAvalancheGoWithVersion_MultipleCoinstalls(t *testing.T) {
 	require.Equal(binary2, installedBin2)
 }
 
+func Test_installAvalancheGoWithVersion_RequireChecksums(t *testing.T) {
+	require := testutils.SetupTest(t)
+
+	tarBytes := testutils.CreateDummyAvagoTar(require, binary1, version1)
+	app := setupInstallDir(require)
+	// avalanchego releases publish no checksums
+	app.RequireChecksums = true
+
+	mockInstaller := &mocks.Installer{}
+	mockInstaller.On("GetArch").Return("amd64", "linux")
+
+	downloader := NewAvagoDownloader()
+
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", mock.Anything).Return(tarBytes, nil)
+	app.Downloader = &mockAppDownloader
+
+	_, err := installBinaryWithVersion(app, version1, app.GetAvalanchegoBinDir(), avalanchegoBinPrefix, downloader, mockInstaller)
+	require.ErrorIs(err, ErrNoReleaseChecksums)
+	require.NoDirExists(filepath.Join(app.GetAvalanchegoBinDir(), avalanchegoBinPrefix+version1))
+}
+
 func Test_installSubnetEVMWithVersion(t *testing.T) {
 	require := testutils.SetupTest(t)
 
@@ -152,9 +184,12 @@ func Test_installSubnetEVMWithVersion(t *testing.T) {
 	mockInstaller.On("GetArch").Return("amd64", "darwin")
 
 	downloader := NewSubnetEVMDownloader()
+	url, _, err := downloader.GetDownloadURL(version1, mockInstaller)
+	require.NoError(err)
 
 	mockAppDownloader := mocks.Downloader{}
-	mockAppDownloader.On("Download", mock.Anything).Return(tarBytes, nil)
+	mockAppDownloader.On("Download", url).Return(tarBytes, nil)
+	mockAppDownloader.On("Download", downloader.GetChecksumsURL(version1)).Return(createChecksumsFile(url, tarBytes), nil)
 	app.Downloader = &mockAppDownloader
 
 	expectedDir := filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1)
@@ -190,6 +225,8 @@ func Test_installSubnetEVMWithVersion_MultipleCoinstalls(t *testing.T) {
 	mockAppDownloader := mocks.Downloader{}
 	mockAppDownloader.On("Download", url1).Return(tarBytes1, nil)
 	mockAppDownloader.On("Download", url2).Return(tarBytes2, nil)
+	mockAppDownloader.On("Download", downloader.GetChecksumsURL(version1)).Return(createChecksumsFile(url1, tarBytes1), nil)
+	mockAppDownloader.On("Download", downloader.GetChecksumsURL(version2)).Return(createChecksumsFile(url2, tarBytes2), nil)
 	app.Downloader = &mockAppDownloader
 
 	expectedDir1 := filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1)
@@ -217,3 +254,66 @@ func Test_installSubnetEVMWithVersion_MultipleCoinstalls(t *testing.T) {
 	require.NoError(err)
 	require.Equal(binary2, installedBin2)
 }
+
+func Test_installSubnetEVMWithVersion_ChecksumMismatch(t *testing.T) {
+	require := testutils.SetupTest(t)
+
+	tarBytes := testutils.CreateDummySubnetEVMTar(require, binary1)
+	app := setupInstallDir(require)
+
+	mockInstaller := &mocks.Installer{}
+	mockInstaller.On("GetArch").Return("amd64", "linux")
+
+	downloader := NewSubnetEVMDownloader()
+	url, _, err := downloader.GetDownloadURL(version1, mockInstaller)
+	require.NoError(err)
+
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", url).Return(tarBytes, nil)
+	mockAppDownloader.On("Download", downloader.GetChecksumsURL(version1)).Return(createChecksumsFile(url, binary2), nil)
+	app.Downloader = &mockAppDownloader
+
+	subDir := filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1)
+
+	_, err = installBinaryWithVersion(app, version1, subDir, subnetEVMBinPrefix, downloader, mockInstaller)
+	require.ErrorIs(err, ErrChecksumMismatch)
+
+	// nothing was extracted
+	_, err = os.Stat(filepath.Join(subDir, constants.SubnetEVMBin))
+	require.True(os.IsNotExist(err))
+}
+
+func TestVerifyInstalledBinary(t *testing.T) {
+	require := testutils.SetupTest(t)
+
+	tarBytes := testutils.CreateDummySubnetEVMTar(require, binary1)
+	app := setupInstallDir(require)
+
+	mockInstaller := &mocks.Installer{}
+	mockInstaller.On("GetArch").Return("amd64", "linux")
+
+	downloader := NewSubnetEVMDownloader()
+	url, _, err := downloader.GetDownloadURL(version1, mockInstaller)
+	require.NoError(err)
+
+	mockAppDownloader := mocks.Downloader{}
+	mockAppDownloader.On("Download", url).Return(tarBytes, nil)
+	mockAppDownloader.On("Download", downloader.GetChecksumsURL(version1)).Return(createChecksumsFile(url, tarBytes), nil)
+	app.Downloader = &mockAppDownloader
+
+	subDir := filepath.Join(app.GetSubnetEVMBinDir(), subnetEVMBinPrefix+version1)
+
+	binDir, err := installBinaryWithVersion(app, version1, subDir, subnetEVMBinPrefix, downloader, mockInstaller)
+	require.NoError(err)
+	require.FileExists(filepath.Join(binDir, constants.BinaryChecksumsFileName))
+	require.NoError(VerifyInstalledBinary(app, binDir))
+
+	// tamper with the installed binary
+	require.NoError(os.WriteFile(filepath.Join(binDir, constants.SubnetEVMBin), binary2, 0o600))
+	require.ErrorIs(VerifyInstalledBinary(app, binDir), ErrChecksumMismatch)
+}
+
+// returns a release checksums file holding the sha256 of [archive] for the file of [archiveURL]
+func createChecksumsFile(archiveURL string, archive []byte) []byte {
+	return []byte(utils.GetSHA256(archive) + "  " + path.Base(archiveURL) + "\n")
+}
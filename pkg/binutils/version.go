@@ -0,0 +1,71 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const versionCmdTimeout = 10 * time.Second
+
+var (
+	ErrUnexpectedVersionOutput = errors.New("unexpected version output")
+
+	// matches the application name and version the binary starts its output
+	// with, as avalanche/1.9.4 or Subnet-EVM/v0.4.8
+	binaryVersionRegex = regexp.MustCompile(`^[\w.-]+/v?(\d+\.\d+\.\d+[\w.+-]*)`)
+	rpcVersionRegex    = regexp.MustCompile(`rpcchainvm=(\d+)`)
+)
+
+// BinaryVersion is the version a binary reports with --version
+type BinaryVersion struct {
+	// semantic version, with a v prefix
+	Version string
+	// RPC protocol version, or 0 if the binary doesn't report it
+	RPCVersion int
+}
+
+// GetBinaryVersion runs the binary at [binPath] with --version and parses its output,
+// as "avalanche/1.9.4 [database=v1.4.5, rpcchainvm=20, commit=...]" for avalanchego or
+// "Subnet-EVM/v0.4.8 [AvalancheGo=v1.9.4, rpcchainvm=20]" for Subnet-EVM
+func GetBinaryVersion(binPath string) (BinaryVersion, error) {
+	info, err := os.Stat(binPath)
+	if err != nil {
+		return BinaryVersion{}, fmt.Errorf("binary %s not found: %w", binPath, err)
+	}
+	if info.IsDir() || info.Mode()&0o111 == 0 {
+		return BinaryVersion{}, fmt.Errorf("%s is not an executable file", binPath)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), versionCmdTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, binPath, "--version").CombinedOutput()
+	if err != nil {
+		return BinaryVersion{}, fmt.Errorf("failed getting version of %s: %w: %s", binPath, err, out)
+	}
+	return parseBinaryVersion(string(out))
+}
+
+func parseBinaryVersion(out string) (BinaryVersion, error) {
+	firstLine := strings.TrimSpace(strings.SplitN(strings.TrimSpace(out), "\n", 2)[0])
+	matches := binaryVersionRegex.FindStringSubmatch(firstLine)
+	if matches == nil {
+		return BinaryVersion{}, fmt.Errorf("%w: %q", ErrUnexpectedVersionOutput, firstLine)
+	}
+	binaryVersion := BinaryVersion{Version: "v" + matches[1]}
+	if matches := rpcVersionRegex.FindStringSubmatch(firstLine); matches != nil {
+		rpcVersion, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return BinaryVersion{}, fmt.Errorf("%w: %q", ErrUnexpectedVersionOutput, firstLine)
+		}
+		binaryVersion.RPCVersion = rpcVersion
+	}
+	return binaryVersion, nil
+}
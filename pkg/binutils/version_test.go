@@ -0,0 +1,51 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package binutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBinaryVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		out      string
+		expected BinaryVersion
+		err      error
+	}{
+		{
+			name:     "avalanchego",
+			out:      "avalanche/1.9.4 [database=v1.4.5, rpcchainvm=20, commit=a3b8fd4d3a8ce4c0c8a4c1d1b2e5f8e3c4a0b1c2]\n",
+			expected: BinaryVersion{Version: "v1.9.4", RPCVersion: 20},
+		},
+		{
+			name:     "subnet-evm",
+			out:      "Subnet-EVM/v0.4.8 [AvalancheGo=v1.9.4, rpcchainvm=20]",
+			expected: BinaryVersion{Version: "v0.4.8", RPCVersion: 20},
+		},
+		{
+			name:     "no rpc version",
+			out:      "spacesvm/v0.0.12-patched",
+			expected: BinaryVersion{Version: "v0.0.12-patched"},
+		},
+		{
+			name: "no version",
+			out:  "flag provided but not defined: -version",
+			err:  ErrUnexpectedVersionOutput,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			binaryVersion, err := parseBinaryVersion(tt.out)
+			if tt.err != nil {
+				require.ErrorIs(err, tt.err)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, binaryVersion)
+		})
+	}
+}
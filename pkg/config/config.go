@@ -5,10 +5,14 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/spf13/viper"
 )
 
+var errInvalidNodeConfigs = errors.New("node-configs must map node names to avalanchego config objects")
+
 type Config struct{}
 
 func New() *Config {
@@ -26,3 +30,27 @@ func (*Config) LoadNodeConfig() (string, error) {
 	}
 	return string(configStr), nil
 }
+
+// LoadNodeConfigs returns the avalanchego configs of specific nodes of the
+// local network, by node name
+func (*Config) LoadNodeConfigs() (map[string]string, error) {
+	nodeConfigs := viper.GetStringMap("node-configs")
+	configStrs := map[string]string{}
+	for nodeName, nodeConfig := range nodeConfigs {
+		if _, ok := nodeConfig.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("%w: invalid config for %s", errInvalidNodeConfigs, nodeName)
+		}
+		configStr, err := json.Marshal(nodeConfig)
+		if err != nil {
+			return nil, err
+		}
+		configStrs[nodeName] = string(configStr)
+	}
+	return configStrs, nil
+}
+
+// LoadReleaseMirror returns the base URL of the mirror to get releases and
+// compatibility data from instead of GitHub, or "" if none is configured
+func (*Config) LoadReleaseMirror() string {
+	return viper.GetString("release-mirror")
+}
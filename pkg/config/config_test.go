@@ -52,6 +52,26 @@ func Test_LoadNodeConfig_NoConfig(t *testing.T) {
 	require.Empty(config)
 }
 
+func Test_LoadNodeConfigs(t *testing.T) {
+	require := require.New(t)
+	cf := New()
+
+	err := useViper("node-configs-test")
+	require.NoError(err)
+
+	configs, err := cf.LoadNodeConfigs()
+	require.NoError(err)
+	require.Len(configs, 2)
+	require.JSONEq(`{"log-level":"debug"}`, configs["node1"])
+	require.JSONEq(`{"http-host":"0.0.0.0","index-enabled":true}`, configs["node3"])
+
+	err = useViper("empty-config")
+	require.NoError(err)
+	configs, err = cf.LoadNodeConfigs()
+	require.NoError(err)
+	require.Empty(configs)
+}
+
 func useViper(configName string) error {
 	viper.Reset()
 	viper.SetConfigName(configName)
@@ -11,10 +11,18 @@ const (
 
 	BaseDirName = ".avalanche-cli"
 	LogDir      = "logs"
+	// the CLI logs to <LogDir>/<CLILogName>.log
+	CLILogName = "avalanche"
 
 	ServerRunFile      = "gRPCserver.run"
 	AvalancheCliBinDir = "bin"
+	DownloadCacheDir   = "cache"
 	RunDir             = "runs"
+	MetricsDir         = "metrics"
+	LocksDir           = "locks"
+
+	// max time to wait for another CLI process to release a lock
+	LockTimeout = 2 * time.Minute
 
 	SuffixSeparator = "_"
 	SidecarFileName = "sidecar.json"
@@ -30,6 +38,9 @@ const (
 
 	RequestTimeout = 3 * time.Minute
 
+	TxCommitTimeout      = 2 * time.Minute
+	TxStatusPollInterval = 1 * time.Second
+
 	SimulatePublicNetwork = "SIMULATE_PUBLIC_NETWORK"
 	FujiAPIEndpoint       = "https://api.avax-test.network"
 	MainnetAPIEndpoint    = "https://api.avax.network"
@@ -37,6 +48,18 @@ const (
 	// this depends on bootstrap snapshot
 	LocalAPIEndpoint = "http://127.0.0.1:9650"
 	LocalNetworkID   = 1337
+	// number of nodes of the bootstrap snapshot
+	LocalNetworkNumNodes = 5
+
+	// named local networks live in their own dir, with their own backend ports.
+	// the default one keeps using the base dir and the default ports
+	LocalNetworksDir         = "local-networks"
+	LocalNetworkFileName     = "local-network.json"
+	SelectedLocalNetworkFile = "selected"
+	DefaultLocalNetworkName  = "default"
+	DefaultGRPCServerPort    = 8097
+	DefaultGRPCGatewayPort   = 8098
+	LocalNetworkBackendFlag  = "local-network"
 
 	DefaultTokenName = "TEST"
 
@@ -50,6 +73,8 @@ const (
 	BootstrapSnapshotLocalPath   = "assets/" + BootstrapSnapshotArchiveName
 	BootstrapSnapshotURL         = "https://github.com/ava-labs/avalanche-cli/raw/main/" + BootstrapSnapshotLocalPath
 	BootstrapSnapshotSHA256URL   = "https://github.com/ava-labs/avalanche-cli/raw/main/assets/sha256sum.txt"
+	// snapshots saved while restarting the network have this infix in their names
+	TmpSnapshotInfix = "-tmp-"
 
 	KeyDir     = "key"
 	KeySuffix  = ".pk"
@@ -71,6 +96,7 @@ const (
 	DefaultConfigFileType = "json"
 
 	CustomVMDir = "vms"
+	VMBackupDir = "vm_backups"
 
 	AvaLabsOrg          = "ava-labs"
 	AvalancheGoRepoName = "avalanchego"
@@ -81,8 +107,12 @@ const (
 	SubnetEVMInstallDir   = "subnet-evm"
 	SpacesVMInstallDir    = "spacesvm"
 
-	SubnetEVMBin = "subnet-evm"
-	SpacesVMBin  = "spacesvm"
+	AvalancheGoBin = "avalanchego"
+	SubnetEVMBin   = "subnet-evm"
+	SpacesVMBin    = "spacesvm"
+
+	// records the checksums of a release archive and of the files installed from it
+	BinaryChecksumsFileName = "checksums.json"
 
 	DefaultNodeRunURL = "http://127.0.0.1:9650"
 
@@ -94,6 +124,8 @@ const (
 	// #nosec G101
 	GithubAPITokenEnvVarName = "AVALANCHE_CLI_GITHUB_TOKEN"
 
+	RequireChecksumsEnvVarName = "AVALANCHE_CLI_REQUIRE_CHECKSUMS"
+
 	ReposDir       = "repos"
 	SubnetDir      = "subnets"
 	VMDir          = "vms"
@@ -0,0 +1,149 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnetworkinterface
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+// avalanchego, the network runner and the CLI all log lines starting
+// with [01-02|15:04:05.000] LEVEL
+const logTimestampFormat = "01-02|15:04:05.000"
+
+var (
+	logLineRegex   = regexp.MustCompile(`^\[(\d\d-\d\d\|\d\d:\d\d:\d\d\.\d{3})\]\s+([A-Z]+)`)
+	colorCodeRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// LogSource is a log file, with the name shown next to its lines
+type LogSource struct {
+	Name string
+	Path string
+}
+
+type LogLine struct {
+	Source string
+	Time   time.Time
+	Level  logging.Level
+	Text   string
+}
+
+// LogReader reads the lines added to a set of log files since its last read
+type LogReader struct {
+	sources []LogSource
+	offsets map[string]int64
+	// last line read from each source, its timestamp and level are given
+	// to the following lines without their own (ex: stack traces)
+	last map[string]LogLine
+}
+
+func NewLogReader(sources []LogSource) *LogReader {
+	return &LogReader{
+		sources: sources,
+		offsets: map[string]int64{},
+		last:    map[string]LogLine{},
+	}
+}
+
+// Read returns the new lines of all sources with at least [minLevel], interleaved
+// by timestamp. Sources that don't exist yet are skipped
+func (r *LogReader) Read(minLevel logging.Level) ([]LogLine, error) {
+	lines := []LogLine{}
+	for _, source := range r.sources {
+		sourceLines, err := r.readSource(source)
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range sourceLines {
+			if line.Level >= minLevel {
+				lines = append(lines, line)
+			}
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Time.Before(lines[j].Time)
+	})
+	return lines, nil
+}
+
+func (r *LogReader) readSource(source LogSource) ([]LogLine, error) {
+	f, err := os.Open(source.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := r.offsets[source.Path]
+	// the file was rotated or truncated, read it from the start
+	if info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	lines := []LogLine{}
+	last, ok := r.last[source.Path]
+	if !ok {
+		last = LogLine{Source: source.Name, Level: logging.Info}
+	}
+	reader := bufio.NewReader(f)
+	for {
+		text, err := reader.ReadString('\n')
+		// only complete lines are consumed, the rest is read once finished
+		if err != nil {
+			break
+		}
+		offset += int64(len(text))
+		last = ParseLogLine(source.Name, strings.TrimRight(text, "\r\n"), last)
+		lines = append(lines, last)
+	}
+	r.offsets[source.Path] = offset
+	r.last[source.Path] = last
+	return lines, nil
+}
+
+// ParseLogLine parses a line of [source]. Lines without a timestamp take the
+// timestamp and level of the [previous] line
+func ParseLogLine(source string, text string, previous LogLine) LogLine {
+	text = colorCodeRegex.ReplaceAllString(text, "")
+	line := LogLine{
+		Source: source,
+		Time:   previous.Time,
+		Level:  previous.Level,
+		Text:   text,
+	}
+	matches := logLineRegex.FindStringSubmatch(text)
+	if matches == nil {
+		return line
+	}
+	if t, err := time.ParseInLocation(logTimestampFormat, matches[1], time.Local); err == nil {
+		line.Time = t
+	}
+	if level, err := logging.ToLevel(matches[2]); err == nil {
+		line.Level = level
+	}
+	return line
+}
+
+// TailLogLines returns the last [n] lines, or all of them if [n] is 0
+func TailLogLines(lines []LogLine, n int) []LogLine {
+	if n <= 0 || len(lines) <= n {
+		return lines
+	}
+	return lines[len(lines)-n:]
+}
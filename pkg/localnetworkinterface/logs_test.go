@@ -0,0 +1,74 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnetworkinterface
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLine(t *testing.T) {
+	require := require.New(t)
+
+	line := ParseLogLine("node1/C", "\x1b[33m[01-02|15:04:05.123] WARN\x1b[0m chain/handler.go:10 slow block", LogLine{})
+	require.Equal("node1/C", line.Source)
+	require.Equal(logging.Warn, line.Level)
+	require.Equal("[01-02|15:04:05.123] WARN chain/handler.go:10 slow block", line.Text)
+	require.Equal(15, line.Time.Hour())
+	require.Equal(123000000, line.Time.Nanosecond())
+
+	continuation := ParseLogLine("node1/C", "goroutine 1 [running]:", line)
+	require.Equal(line.Time, continuation.Time)
+	require.Equal(logging.Warn, continuation.Level)
+}
+
+func TestLogReader(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	node1Log := filepath.Join(dir, "node1.log")
+	node2Log := filepath.Join(dir, "node2.log")
+	require.NoError(os.WriteFile(node1Log, []byte(
+		"[01-02|15:04:01.000] INFO first\n"+
+			"[01-02|15:04:03.000] ERROR third\n"+
+			"stack trace\n"+
+			"[01-02|15:04:05.000] INFO partial"), 0o600))
+	require.NoError(os.WriteFile(node2Log, []byte("[01-02|15:04:02.000] DEBUG second\n"), 0o600))
+
+	reader := NewLogReader([]LogSource{
+		{Name: "node1", Path: node1Log},
+		{Name: "node2", Path: node2Log},
+		{Name: "missing", Path: filepath.Join(dir, "missing.log")},
+	})
+	lines, err := reader.Read(logging.Verbo)
+	require.NoError(err)
+	texts := []string{}
+	for _, line := range lines {
+		texts = append(texts, line.Source+": "+line.Text)
+	}
+	require.Equal([]string{
+		"node1: [01-02|15:04:01.000] INFO first",
+		"node2: [01-02|15:04:02.000] DEBUG second",
+		"node1: [01-02|15:04:03.000] ERROR third",
+		"node1: stack trace",
+	}, texts)
+
+	// the partial line is read once finished, filtering by level
+	f, err := os.OpenFile(node1Log, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(err)
+	_, err = f.WriteString(" line\n[01-02|15:04:06.000] WARN last\n")
+	require.NoError(err)
+	require.NoError(f.Close())
+	lines, err = reader.Read(logging.Warn)
+	require.NoError(err)
+	require.Len(lines, 1)
+	require.Equal("[01-02|15:04:06.000] WARN last", lines[0].Text)
+
+	require.Equal(lines, TailLogLines(lines, 5))
+	require.Len(TailLogLines([]LogLine{{}, {}, {}}, 2), 2)
+}
@@ -8,7 +8,6 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanchego/api/info"
 )
 
@@ -16,15 +15,20 @@ type StatusChecker interface {
 	GetCurrentNetworkVersion() (string, int, bool, error)
 }
 
-type networkStatusChecker struct{}
+type networkStatusChecker struct {
+	apiEndpoint string
+}
 
-func NewStatusChecker() StatusChecker {
-	return networkStatusChecker{}
+// NewStatusChecker returns a checker for the local network node at [apiEndpoint]
+func NewStatusChecker(apiEndpoint string) StatusChecker {
+	return networkStatusChecker{
+		apiEndpoint: apiEndpoint,
+	}
 }
 
-func (networkStatusChecker) GetCurrentNetworkVersion() (string, int, bool, error) {
+func (nc networkStatusChecker) GetCurrentNetworkVersion() (string, int, bool, error) {
 	ctx := context.Background()
-	infoClient := info.NewClient(constants.LocalAPIEndpoint)
+	infoClient := info.NewClient(nc.apiEndpoint)
 	versionResponse, err := infoClient.GetNodeVersion(ctx)
 	if err != nil {
 		// not actually an error, network just not running
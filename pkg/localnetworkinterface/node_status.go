@@ -0,0 +1,72 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnetworkinterface
+
+import (
+	"context"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/info"
+)
+
+// timeout of each call to a node, so a paused or dead node does not
+// block the status of the rest of the network
+const nodeRequestTimeout = 3 * time.Second
+
+type ChainStatus struct {
+	Bootstrapped bool
+	Healthy      bool
+}
+
+type NodeStatus struct {
+	// false if the node APIs could not be reached
+	Reachable bool
+	Version   string
+	Healthy   bool
+	// status of each chain, by blockchain ID
+	Chains map[string]ChainStatus
+}
+
+// GetNodeStatus queries the node at [uri] for its version and health, and for
+// the bootstrap and health status of the blockchains in [blockchainIDs]
+func GetNodeStatus(uri string, blockchainIDs []string) NodeStatus {
+	status := NodeStatus{
+		Chains: map[string]ChainStatus{},
+	}
+	infoClient := info.NewClient(uri)
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeRequestTimeout)
+	defer cancel()
+	versionResponse, err := infoClient.GetNodeVersion(ctx)
+	if err != nil {
+		return status
+	}
+	status.Reachable = true
+	status.Version = versionResponse.Version
+
+	// the health checks of each chain are registered under its blockchain ID
+	var checks map[string]health.Result
+	healthCtx, healthCancel := context.WithTimeout(context.Background(), nodeRequestTimeout)
+	defer healthCancel()
+	healthResponse, err := health.NewClient(uri).Health(healthCtx)
+	if err == nil {
+		status.Healthy = healthResponse.Healthy
+		checks = healthResponse.Checks
+	}
+
+	for _, blockchainID := range blockchainIDs {
+		chainCtx, chainCancel := context.WithTimeout(context.Background(), nodeRequestTimeout)
+		bootstrapped, err := infoClient.IsBootstrapped(chainCtx, blockchainID)
+		chainCancel()
+		chainStatus := ChainStatus{
+			Bootstrapped: err == nil && bootstrapped,
+		}
+		if check, ok := checks[blockchainID]; ok {
+			chainStatus.Healthy = check.Error == nil
+		}
+		status.Chains[blockchainID] = chainStatus
+	}
+	return status
+}
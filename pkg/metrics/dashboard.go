@@ -0,0 +1,145 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	panelWidth  = 12
+	panelHeight = 8
+	// grafana dashboards are 24 units wide
+	panelsPerRow = 2
+)
+
+type panelSpec struct {
+	title string
+	expr  string
+	unit  string
+}
+
+// avalanchego metrics of each node
+var nodePanels = []panelSpec{
+	{"Connected peers", `avalanche_network_peers{node=~"$node"}`, "short"},
+	{"Failing health checks", `avalanche_health_checks_failing{node=~"$node"}`, "short"},
+	{"Resident memory", `process_resident_memory_bytes{node=~"$node"}`, "bytes"},
+	{"CPU usage", `rate(process_cpu_seconds_total{node=~"$node"}[1m])`, "percentunit"},
+}
+
+// consensus metrics of each chain, prefixed by avalanche_<blockchainID>_,
+// followed by the Subnet-EVM metrics, prefixed by avalanche_<blockchainID>_vm_
+var chainPanels = []panelSpec{
+	{"Accepted blocks / s", `rate(avalanche_%s_blks_accepted_count{node=~"$node"}[1m])`, "short"},
+	{"Processing blocks", `avalanche_%s_blks_processing{node=~"$node"}`, "short"},
+	{"Block accept latency", `rate(avalanche_%[1]s_blks_accepted_sum{node=~"$node"}[1m]) / rate(avalanche_%[1]s_blks_accepted_count{node=~"$node"}[1m])`, "ns"},
+	{"Rejected blocks / s", `rate(avalanche_%s_blks_rejected_count{node=~"$node"}[1m])`, "short"},
+	{"Pending txs (Subnet-EVM)", `avalanche_%s_vm_txpool_pending{node=~"$node"}`, "short"},
+	{"Gas used / s (Subnet-EVM)", `rate(avalanche_%s_vm_chain_block_gas_used_accepted{node=~"$node"}[1m])`, "short"},
+}
+
+// GrafanaDashboard returns a starter Grafana dashboard for the nodes of a local
+// network, with a row of avalanchego metrics and a row of metrics per chain.
+// It takes the Prometheus datasource and the nodes to show as variables
+func GrafanaDashboard(title string, chains []Chain) ([]byte, error) {
+	panels := []map[string]interface{}{}
+	y := 0
+	nextID := 1
+	addRow := func(rowTitle string, specs []panelSpec, exprArg string) {
+		panels = append(panels, map[string]interface{}{
+			"id":      nextID,
+			"type":    "row",
+			"title":   rowTitle,
+			"gridPos": gridPos(0, y, 24, 1),
+		})
+		nextID++
+		y++
+		for i, spec := range specs {
+			expr := spec.expr
+			if exprArg != "" {
+				expr = fmt.Sprintf(spec.expr, exprArg)
+			}
+			panels = append(panels, timeseriesPanel(nextID, spec.title, expr, spec.unit, (i%panelsPerRow)*panelWidth, y+(i/panelsPerRow)*panelHeight))
+			nextID++
+		}
+		y += ((len(specs) + panelsPerRow - 1) / panelsPerRow) * panelHeight
+	}
+
+	addRow("Nodes", nodePanels, "")
+	for _, chain := range chains {
+		addRow(fmt.Sprintf("Chain %s (%s)", chain.Name, chain.BlockchainID), chainPanels, chain.BlockchainID)
+	}
+
+	dashboard := map[string]interface{}{
+		"title":         title,
+		"uid":           nil,
+		"schemaVersion": 36,
+		"refresh":       "10s",
+		"time": map[string]interface{}{
+			"from": "now-30m",
+			"to":   "now",
+		},
+		"templating": map[string]interface{}{
+			"list": []map[string]interface{}{
+				{
+					"name":  "datasource",
+					"label": "Datasource",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+				{
+					"name":       "node",
+					"label":      "Node",
+					"type":       "query",
+					"datasource": datasourceRef(),
+					"query":      "label_values(node)",
+					"multi":      true,
+					"includeAll": true,
+					"current": map[string]interface{}{
+						"text":  "All",
+						"value": "$__all",
+					},
+					"refresh": 2,
+				},
+			},
+		},
+		"panels": panels,
+	}
+	return json.MarshalIndent(dashboard, "", "  ")
+}
+
+func timeseriesPanel(id int, title string, expr string, unit string, x int, y int) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"type":       "timeseries",
+		"title":      title,
+		"datasource": datasourceRef(),
+		"gridPos":    gridPos(x, y, panelWidth, panelHeight),
+		"fieldConfig": map[string]interface{}{
+			"defaults": map[string]interface{}{
+				"unit": unit,
+			},
+			"overrides": []interface{}{},
+		},
+		"targets": []map[string]interface{}{
+			{
+				"refId":        "A",
+				"datasource":   datasourceRef(),
+				"expr":         expr,
+				"legendFormat": "{{node}}",
+			},
+		},
+	}
+}
+
+func gridPos(x int, y int, w int, h int) map[string]int {
+	return map[string]int{"x": x, "y": y, "w": w, "h": h}
+}
+
+func datasourceRef() map[string]string {
+	return map[string]string{
+		"type": "prometheus",
+		"uid":  "${datasource}",
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	PrometheusConfigFileName  = "prometheus.yml"
+	PrometheusTargetsFileName = "targets.json"
+	GrafanaDashboardFileName  = "grafana-dashboard.json"
+
+	// avalanchego serves its metrics at this path of its API
+	metricsPath    = "/ext/metrics"
+	scrapeInterval = "5s"
+)
+
+// Target is a node to scrape
+type Target struct {
+	NodeName string
+	NodeID   string
+	URI      string
+}
+
+// Chain is a custom chain to show in the dashboard
+type Chain struct {
+	Name         string
+	BlockchainID string
+}
+
+type prometheusConfig struct {
+	Global        prometheusGlobal         `yaml:"global"`
+	ScrapeConfigs []prometheusScrapeConfig `yaml:"scrape_configs"`
+}
+
+type prometheusGlobal struct {
+	ScrapeInterval string `yaml:"scrape_interval"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName       string                   `yaml:"job_name"`
+	MetricsPath   string                   `yaml:"metrics_path"`
+	FileSDConfigs []prometheusFileSDConfig `yaml:"file_sd_configs"`
+}
+
+type prometheusFileSDConfig struct {
+	Files []string `yaml:"files"`
+}
+
+type prometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// PrometheusConfig returns a Prometheus config scraping the targets listed at
+// [targetsPath]. Prometheus reloads that file when it changes, so nodes can be
+// added or moved without restarting it
+func PrometheusConfig(jobName string, targetsPath string) ([]byte, error) {
+	return yaml.Marshal(prometheusConfig{
+		Global: prometheusGlobal{
+			ScrapeInterval: scrapeInterval,
+		},
+		ScrapeConfigs: []prometheusScrapeConfig{
+			{
+				JobName:     jobName,
+				MetricsPath: metricsPath,
+				FileSDConfigs: []prometheusFileSDConfig{
+					{Files: []string{targetsPath}},
+				},
+			},
+		},
+	})
+}
+
+// PrometheusTargets returns the Prometheus file based service discovery
+// targets of the given nodes, labeled with their name and ID
+func PrometheusTargets(targets []Target) ([]byte, error) {
+	groups := []prometheusTargetGroup{}
+	for _, target := range targets {
+		u, err := url.Parse(target.URI)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI %q of node %s: %w", target.URI, target.NodeName, err)
+		}
+		groups = append(groups, prometheusTargetGroup{
+			Targets: []string{u.Host},
+			Labels: map[string]string{
+				"node":    target.NodeName,
+				"node_id": target.NodeID,
+			},
+		})
+	}
+	return json.MarshalIndent(groups, "", "  ")
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package metrics
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestPrometheusConfig(t *testing.T) {
+	require := require.New(t)
+
+	configBytes, err := PrometheusConfig("avalanche-default", "/tmp/metrics/targets.json")
+	require.NoError(err)
+
+	var config prometheusConfig
+	require.NoError(yaml.Unmarshal(configBytes, &config))
+	require.Len(config.ScrapeConfigs, 1)
+	require.Equal("avalanche-default", config.ScrapeConfigs[0].JobName)
+	require.Equal(metricsPath, config.ScrapeConfigs[0].MetricsPath)
+	require.Equal([]string{"/tmp/metrics/targets.json"}, config.ScrapeConfigs[0].FileSDConfigs[0].Files)
+}
+
+func TestPrometheusTargets(t *testing.T) {
+	require := require.New(t)
+
+	targetsBytes, err := PrometheusTargets([]Target{
+		{NodeName: "node1", NodeID: "NodeID-1", URI: "http://127.0.0.1:9650"},
+		{NodeName: "node2", NodeID: "NodeID-2", URI: "http://127.0.0.1:9652"},
+	})
+	require.NoError(err)
+
+	var groups []prometheusTargetGroup
+	require.NoError(json.Unmarshal(targetsBytes, &groups))
+	require.Len(groups, 2)
+	require.Equal([]string{"127.0.0.1:9650"}, groups[0].Targets)
+	require.Equal("node1", groups[0].Labels["node"])
+	require.Equal("NodeID-2", groups[1].Labels["node_id"])
+
+	_, err = PrometheusTargets([]Target{{NodeName: "node1", URI: "://bad"}})
+	require.Error(err)
+}
+
+func TestGrafanaDashboard(t *testing.T) {
+	require := require.New(t)
+
+	dashboardBytes, err := GrafanaDashboard("test", []Chain{
+		{Name: "subnetA", BlockchainID: "chainA"},
+	})
+	require.NoError(err)
+
+	var dashboard map[string]interface{}
+	require.NoError(json.Unmarshal(dashboardBytes, &dashboard))
+	require.Equal("test", dashboard["title"])
+
+	rows := 0
+	chainExprs := 0
+	for _, panel := range dashboard["panels"].([]interface{}) {
+		p := panel.(map[string]interface{})
+		if p["type"] == "row" {
+			rows++
+			continue
+		}
+		for _, target := range p["targets"].([]interface{}) {
+			if strings.Contains(target.(map[string]interface{})["expr"].(string), "chainA") {
+				chainExprs++
+			}
+		}
+	}
+	require.Equal(2, rows)
+	require.Positive(chainExprs)
+}
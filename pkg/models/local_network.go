@@ -0,0 +1,37 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+)
+
+// LocalNetwork holds the settings that isolate a local network from the
+// other ones running side by side on the same machine
+type LocalNetwork struct {
+	Name            string
+	GRPCPort        int
+	GRPCGatewayPort int
+	// API endpoint of the first node, as of the last time the network was (re)started
+	APIEndpoint string
+}
+
+// NewDefaultLocalNetwork returns the settings of the default local network
+func NewDefaultLocalNetwork() LocalNetwork {
+	return LocalNetwork{
+		Name:            constants.DefaultLocalNetworkName,
+		GRPCPort:        constants.DefaultGRPCServerPort,
+		GRPCGatewayPort: constants.DefaultGRPCGatewayPort,
+		APIEndpoint:     constants.LocalAPIEndpoint,
+	}
+}
+
+func (ln LocalNetwork) GRPCServerEndpoint() string {
+	return fmt.Sprintf(":%d", ln.GRPCPort)
+}
+
+func (ln LocalNetwork) GRPCGatewayEndpoint() string {
+	return fmt.Sprintf(":%d", ln.GRPCGatewayPort)
+}
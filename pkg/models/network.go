@@ -42,6 +42,24 @@ func (s Network) NetworkID() (uint32, error) {
 	return 0, fmt.Errorf("unsupported network")
 }
 
+// returns the public API endpoint of the network. The endpoint of the local
+// network depends on the target local network, see Avalanche.GetAPIEndpoint
+func (s Network) Endpoint() (string, error) {
+	switch s {
+	case Mainnet:
+		return constants.MainnetAPIEndpoint, nil
+	case Fuji:
+		return constants.FujiAPIEndpoint, nil
+	}
+	return "", fmt.Errorf("unsupported network")
+}
+
+// LocalNetworkKey returns the key of the deployment data of a subnet on local
+// network [name] in the sidecar, as each named local network has its own
+func LocalNetworkKey(name string) string {
+	return fmt.Sprintf("%s (%s)", Local.String(), name)
+}
+
 func NetworkFromString(s string) Network {
 	switch s {
 	case Mainnet.String():
@@ -10,6 +10,10 @@ import (
 type NetworkData struct {
 	SubnetID     ids.ID
 	BlockchainID ids.ID
+	// VM version running on the network nodes, and the one it replaced
+	// on the last VM upgrade (used for rollbacks)
+	VMVersion         string
+	PreviousVMVersion string
 }
 
 type Sidecar struct {
@@ -24,6 +28,10 @@ type Sidecar struct {
 	Networks        map[string]NetworkData
 	ImportedFromAPM bool
 	ImportedVMID    string
+	// locally built binaries of the last local deploy, used instead of
+	// the released VMVersion and avalanchego
+	VMPath          string
+	AvalancheGoPath string
 }
 
 func (sc Sidecar) GetVMID() (string, error) {
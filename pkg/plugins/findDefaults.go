@@ -4,11 +4,14 @@
 package plugins
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/config"
@@ -25,6 +28,8 @@ var (
 	defaultConfigFileName = "config.json"
 	// expected name of the plugins dir
 	defaultPluginDir = "plugins"
+	// default chain config dir, relative to the avalanchego data dir
+	defaultChainConfigDir = filepath.Join("configs", "chains")
 	// default dir where the binary is usually found
 	defaultAvalanchegoBuildDir = filepath.Join("go", "src", "github.com", constants.AvaLabsOrg, constants.AvalancheGoRepoName, "build")
 )
@@ -75,11 +80,13 @@ func FindPluginDir() (string, error) {
 	return "", nil
 }
 
-func FindAvagoConfigPath() (string, error) {
+// FindAvagoConfigPath looks for the avalanchego config file, ignoring the nodes
+// of the local network, which run under the CLI run dir
+func FindAvagoConfigPath(app *application.Avalanche) (string, error) {
 	ux.Logger.PrintToUser(logging.Yellow.Wrap("Scanning your system for existing files..."))
 	var path string
 	// Attempt 1: Try the admin API
-	if path = findByRunningProcesses(constants.AvalancheGoRepoName, config.ConfigFileKey); path != "" {
+	if path = findByRunningProcesses(constants.AvalancheGoRepoName, config.ConfigFileKey, app.GetRunDir()); path != "" {
 		return path, nil
 	}
 	// Attempt 2: find looking at some usual dirs
@@ -94,6 +101,65 @@ func FindAvagoConfigPath() (string, error) {
 	return "", nil
 }
 
+// FindAvagoChainConfigDir looks for the chain config dir used by avalanchego:
+// - on the command line of a running avalanchego process, other than the local network ones
+// - on the avalanchego config file (see FindAvagoConfigPath)
+// - on the default location inside common avalanchego data dirs
+func FindAvagoChainConfigDir(app *application.Avalanche) (string, error) {
+	ux.Logger.PrintToUser(logging.Yellow.Wrap("Scanning your system for the chain config directory..."))
+	var dir string
+	// Attempt 1: look at the running processes
+	if dir = findByRunningProcesses(constants.AvalancheGoRepoName, config.ChainConfigDirKey, app.GetRunDir()); dir != "" {
+		return dir, nil
+	}
+	// Attempt 2: look at the avalanchego config file
+	configPath, err := FindAvagoConfigPath(app)
+	if err != nil {
+		return "", err
+	}
+	if configPath != "" {
+		dir, err = getConfigFileEntry(configPath, config.ChainConfigDirKey)
+		if err != nil {
+			return "", err
+		}
+		if dir != "" {
+			return os.ExpandEnv(dir), nil
+		}
+	}
+	// Attempt 3: find looking at some usual dirs
+	scanConfigDirs, err := getScanConfigDirs()
+	if err != nil {
+		return "", err
+	}
+	if dir = findByCommonDirs(defaultChainConfigDir, scanConfigDirs); dir != "" {
+		return dir, nil
+	}
+	ux.Logger.PrintToUser(logging.Yellow.Wrap("No chain config directory has been found on your system"))
+	return "", nil
+}
+
+// returns the string value of [key] on the avalanchego config file at [configPath],
+// or empty string if not present
+func getConfigFileEntry(configPath string, key string) (string, error) {
+	fileBytes, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load avalanchego config file %s: %w", configPath, err)
+	}
+	var avagoConfig map[string]interface{}
+	if err := json.Unmarshal(fileBytes, &avagoConfig); err != nil {
+		return "", fmt.Errorf("failed to unpack the config file %s to JSON: %w", configPath, err)
+	}
+	val, ok := avagoConfig[key]
+	if !ok {
+		return "", nil
+	}
+	valStr, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string value for %s, but got %T", key, val)
+	}
+	return valStr, nil
+}
+
 func findByCommonDirs(filename string, scanDirs []string) string {
 	for _, d := range scanDirs {
 		if d == defaultUnexpandedDataDir {
@@ -107,7 +173,9 @@ func findByCommonDirs(filename string, scanDirs []string) string {
 	return ""
 }
 
-func findByRunningProcesses(procName, key string) string {
+// returns the value of [key] on the command line of a running [procName] process.
+// Processes whose command line contains [excludeDir] are skipped, if given
+func findByRunningProcesses(procName, key string, excludeDir string) string {
 	procs, err := process.Processes()
 	if err != nil {
 		return ""
@@ -122,6 +190,9 @@ func findByRunningProcesses(procName, key string) string {
 			// ignore errors for processes that just died (macos implementation)
 			continue
 		}
+		if excludeDir != "" && strings.Contains(name, excludeDir) {
+			continue
+		}
 		if regex.MatchString(name) {
 			// truncate at end of `--config-file` + 1 (ignores if = or space)
 			trunc := name[strings.Index(name, key)+len(key)+1:]
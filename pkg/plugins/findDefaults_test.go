@@ -43,7 +43,7 @@ func TestFindByRunningProcess(t *testing.T) {
 	// in a go routine (while our target backend process is running):
 	// run the target function and expect the targeted argument to be found
 	go func() {
-		funcValue := findByRunningProcesses(procName, argWithSpace)
+		funcValue := findByRunningProcesses(procName, argWithSpace, "")
 		require.Equal(spaceValue, funcValue)
 		// kill the process right away, we have what we wanted
 		err = cmd.Process.Kill()
@@ -66,7 +66,7 @@ func TestFindByRunningProcess(t *testing.T) {
 	// otherwise `findByRunningProcesses` might be done before that!
 	time.Sleep(250 * time.Millisecond)
 	go func() {
-		funcValue := findByRunningProcesses(procName, argWithEqual)
+		funcValue := findByRunningProcesses(procName, argWithEqual, "")
 		require.Equal(equalValue, funcValue)
 		err = cmd2.Process.Kill()
 		require.NoError(err)
@@ -76,6 +76,31 @@ func TestFindByRunningProcess(t *testing.T) {
 	require.ErrorContains(err, "killed")
 }
 
+// TestFindByRunningProcessExcludeDir checks that processes whose command line
+// contains the excluded dir, as the local network nodes do, are skipped
+func TestFindByRunningProcessExcludeDir(t *testing.T) {
+	require := require.New(t)
+
+	argExcluded := "argExcluded"
+	excludeDir := filepath.Join(t.TempDir(), "run")
+	value := filepath.Join(excludeDir, "node1", "chainConfigs")
+	procName := "sh"
+
+	cs := []string{"-c", `sleep 20; -` + argExcluded + `=` + value}
+	cmd := exec.Command(procName, cs...) // #nosec G204
+	err := cmd.Start()
+	require.NoError(err)
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+	// give the process the time to actually start
+	time.Sleep(250 * time.Millisecond)
+
+	require.Equal(value, findByRunningProcesses(procName, argExcluded, ""))
+	require.Empty(findByRunningProcesses(procName, argExcluded, excludeDir))
+}
+
 func TestFindDefaultFiles(t *testing.T) {
 	testDir := t.TempDir()
 	require := require.New(t)
@@ -153,3 +178,26 @@ func TestFindDefaultFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestGetConfigFileEntry(t *testing.T) {
+	require := require.New(t)
+	testDir := t.TempDir()
+
+	configPath := filepath.Join(testDir, defaultConfigFileName)
+	err := os.WriteFile(configPath, []byte(`{"chain-config-dir":"/path/to/chains","network-id":5}`), constants.DefaultPerms755)
+	require.NoError(err)
+
+	dir, err := getConfigFileEntry(configPath, config.ChainConfigDirKey)
+	require.NoError(err)
+	require.Equal("/path/to/chains", dir)
+
+	dir, err = getConfigFileEntry(configPath, config.PluginDirKey)
+	require.NoError(err)
+	require.Empty(dir)
+
+	_, err = getConfigFileEntry(configPath, "network-id")
+	require.Error(err)
+
+	_, err = getConfigFileEntry(filepath.Join(testDir, "nonexistent.json"), config.ChainConfigDirKey)
+	require.Error(err)
+}
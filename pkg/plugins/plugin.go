@@ -81,27 +81,38 @@ func CreatePluginFromVersion(
 	vmid string,
 	pluginDir string,
 ) (string, error) {
-	var vmSourcePath string
-	var vmDestPath string
-	var err error
+	vmSourcePath, err := setupVMFromVersion(app, subnetName, vm, version)
+	if err != nil {
+		return "", err
+	}
+	vmDestPath := filepath.Join(pluginDir, vmid)
+
+	return vmDestPath, binutils.CopyFile(vmSourcePath, vmDestPath)
+}
 
+// Downloads the target VM (if necessary) and returns the path of its binary
+func setupVMFromVersion(
+	app *application.Avalanche,
+	subnetName string,
+	vm models.VMType,
+	version string,
+) (string, error) {
 	switch vm {
 	case models.SubnetEvm:
-		vmSourcePath, err = binutils.SetupSubnetEVM(app, version)
+		vmSourcePath, err := binutils.SetupSubnetEVM(app, version)
 		if err != nil {
 			return "", fmt.Errorf("failed to install subnet-evm: %w", err)
 		}
+		return vmSourcePath, nil
 	case models.SpacesVM:
-		vmSourcePath, err = binutils.SetupSpacesVM(app, version)
+		vmSourcePath, err := binutils.SetupSpacesVM(app, version)
 		if err != nil {
 			return "", fmt.Errorf("failed to install spaces-vm: %w", err)
 		}
+		return vmSourcePath, nil
 	case models.CustomVM:
-		vmSourcePath = binutils.SetupCustomBin(app, subnetName)
+		return binutils.SetupCustomBin(app, subnetName), nil
 	default:
 		return "", fmt.Errorf("unknown vm: %s", vm)
 	}
-	vmDestPath = filepath.Join(pluginDir, vmid)
-
-	return vmDestPath, binutils.CopyFile(vmSourcePath, vmDestPath)
 }
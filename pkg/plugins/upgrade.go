@@ -4,12 +4,26 @@
 package plugins
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+const swapSuffix = ".swap"
+
+var (
+	ErrNoVMBackup  = errors.New("no previous VM binary backed up for this network")
+	ErrNotDeployed = errors.New("subnet has no deployment on this network")
+)
+
 func ManualUpgrade(app *application.Avalanche, sc models.Sidecar, targetVersion string) error {
 	vmid, err := sc.GetVMID()
 	if err != nil {
@@ -24,19 +38,162 @@ func ManualUpgrade(app *application.Avalanche, sc models.Sidecar, targetVersion
 	return nil
 }
 
-func AutomatedUpgrade(app *application.Avalanche, sc models.Sidecar, targetVersion string, pluginDir string) error {
-	// Attempt an automated update
+// AutomatedUpgrade installs [targetVersion] of the subnet VM into the avalanchego plugin dir
+// of a node deployed on [network]. The previously installed binary is backed up so that
+// it can be restored with RollbackUpgrade, and the deployed version is recorded in the sidecar
+func AutomatedUpgrade(
+	app *application.Avalanche,
+	sc models.Sidecar,
+	network models.Network,
+	targetVersion string,
+	pluginDir string,
+) error {
+	if err := checkDeployed(sc, network); err != nil {
+		return err
+	}
+	pluginDir, err := GetPluginDirToUpgrade(app, pluginDir)
+	if err != nil {
+		return err
+	}
+
+	vmid, err := sc.GetVMID()
+	if err != nil {
+		return err
+	}
+	vmSourcePath, err := setupVMFromVersion(app, sc.Name, sc.VM, targetVersion)
+	if err != nil {
+		return err
+	}
+	vmPath := filepath.Join(pluginDir, vmid)
+	backupPath := app.GetVMBackupPath(sc.Name, network.String())
+
+	// back up the binary currently in use, if any
+	hasBackup := false
+	if _, err := os.Stat(vmPath); err == nil {
+		if err := os.MkdirAll(filepath.Dir(backupPath), constants.DefaultPerms755); err != nil {
+			return err
+		}
+		if err := binutils.CopyFile(vmPath, backupPath); err != nil {
+			return fmt.Errorf("failed backing up previous VM binary: %w", err)
+		}
+		hasBackup = true
+		ux.Logger.PrintToUser("Previous VM binary backed up to %s", backupPath)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := ReplaceBinary(vmSourcePath, vmPath); err != nil {
+		return fmt.Errorf("failed installing VM binary: %w", err)
+	}
+	ux.Logger.PrintToUser("VM binary written to %s", vmPath)
+
+	networkData := sc.Networks[network.String()]
+	if hasBackup {
+		networkData.PreviousVMVersion = networkData.VMVersion
+		if networkData.PreviousVMVersion == "" {
+			networkData.PreviousVMVersion = sc.VMVersion
+		}
+	}
+	networkData.VMVersion = targetVersion
+	sc.Networks[network.String()] = networkData
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return fmt.Errorf("VM binary installed, but failed to update sidecar: %w", err)
+	}
+
+	printRestartMsg()
+	return nil
+}
+
+// RollbackUpgrade restores the VM binary backed up on the last AutomatedUpgrade for [network].
+// The binary being replaced becomes the new backup, so a rollback can be undone
+// with a further rollback
+func RollbackUpgrade(
+	app *application.Avalanche,
+	sc models.Sidecar,
+	network models.Network,
+	pluginDir string,
+) error {
+	if err := checkDeployed(sc, network); err != nil {
+		return err
+	}
+	backupPath := app.GetVMBackupPath(sc.Name, network.String())
+	if _, err := os.Stat(backupPath); errors.Is(err, os.ErrNotExist) {
+		return ErrNoVMBackup
+	} else if err != nil {
+		return err
+	}
+
+	pluginDir, err := GetPluginDirToUpgrade(app, pluginDir)
+	if err != nil {
+		return err
+	}
+	vmid, err := sc.GetVMID()
+	if err != nil {
+		return err
+	}
+	vmPath := filepath.Join(pluginDir, vmid)
+
+	// keep the binary being rolled back, it will become the new backup
+	swapPath := backupPath + swapSuffix
+	hasCurrent := false
+	if _, err := os.Stat(vmPath); err == nil {
+		if err := binutils.CopyFile(vmPath, swapPath); err != nil {
+			return fmt.Errorf("failed backing up current VM binary: %w", err)
+		}
+		hasCurrent = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := ReplaceBinary(backupPath, vmPath); err != nil {
+		return fmt.Errorf("failed restoring VM binary: %w", err)
+	}
+	if hasCurrent {
+		if err := os.Rename(swapPath, backupPath); err != nil {
+			return err
+		}
+	} else if err := os.Remove(backupPath); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("VM binary at %s restored from backup", vmPath)
+
+	networkData := sc.Networks[network.String()]
+	networkData.VMVersion, networkData.PreviousVMVersion = networkData.PreviousVMVersion, networkData.VMVersion
+	sc.Networks[network.String()] = networkData
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return fmt.Errorf("VM binary restored, but failed to update sidecar: %w", err)
+	}
+	if networkData.VMVersion != "" {
+		ux.Logger.PrintToUser("Rolled back to VM version %s", networkData.VMVersion)
+	}
+
+	printRestartMsg()
+	return nil
+}
+
+// the VM versions deployed on [network] are recorded in the sidecar, so there has
+// to be a deployment to record them in
+func checkDeployed(sc models.Sidecar, network models.Network) error {
+	if _, ok := sc.Networks[network.String()]; !ok {
+		return fmt.Errorf("%w: deploy subnet %s to %s first", ErrNotDeployed, sc.Name, network.String())
+	}
+	return nil
+}
+
+// GetPluginDirToUpgrade returns [pluginDir] if given, or else the avalanchego plugin dir found
+// on this machine, asking the user for confirmation, or for the path if none is found
+func GetPluginDirToUpgrade(app *application.Avalanche, pluginDir string) (string, error) {
 	var err error
 	if pluginDir == "" {
 		pluginDir, err = FindPluginDir()
 		if err != nil {
-			return err
+			return "", err
 		}
 		if pluginDir != "" {
 			ux.Logger.PrintToUser(logging.Bold.Wrap(logging.Green.Wrap("Found the VM plugin directory at %s")), pluginDir)
 			yes, err := app.Prompt.CaptureYesNo("Is this where we should upgrade the VM?")
 			if err != nil {
-				return err
+				return "", err
 			}
 			if yes {
 				ux.Logger.PrintToUser("Will use plugin directory at %s to upgrade the VM", pluginDir)
@@ -47,28 +204,28 @@ func AutomatedUpgrade(app *application.Avalanche, sc models.Sidecar, targetVersi
 		if pluginDir == "" {
 			pluginDir, err = app.Prompt.CaptureString("Path to your avalanchego plugin dir (likely ~/.avalanchego/build/plugins)")
 			if err != nil {
-				return err
+				return "", err
 			}
 		}
 	}
 
-	pluginDir, err = SanitizePath(pluginDir)
-	if err != nil {
-		return err
-	}
+	return SanitizePath(pluginDir)
+}
 
-	vmid, err := sc.GetVMID()
-	if err != nil {
-		return err
-	}
-	vmPath, err := CreatePluginFromVersion(app, sc.Name, sc.VM, targetVersion, vmid, pluginDir)
-	if err != nil {
+// ReplaceBinary replaces [dest] with a copy of [src]. The copy is written next to [dest]
+// and then renamed, so a running node never sees a partially written binary, and [dest]
+// is left as it was if the copy fails
+func ReplaceBinary(src, dest string) error {
+	tmpPath := filepath.Join(filepath.Dir(dest), "."+filepath.Base(dest)+swapSuffix)
+	if err := binutils.CopyFile(src, tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
 		return err
 	}
+	return os.Rename(tmpPath, dest)
+}
 
-	ux.Logger.PrintToUser("VM binary written to %s", vmPath)
-
-	return nil
+func printRestartMsg() {
+	ux.Logger.PrintToUser("Restart your node for the new VM binary to be loaded")
 }
 
 func printUpgradeCmd(vmPath string) {
@@ -0,0 +1,32 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package plugins
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeNotDeployed(t *testing.T) {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	// sidecars of subnets never deployed have no networks
+	sc := models.Sidecar{Name: "subnet1", VM: models.SubnetEvm, VMVersion: "v0.4.8"}
+	err := AutomatedUpgrade(app, sc, models.Fuji, "v0.4.9", t.TempDir())
+	require.ErrorIs(err, ErrNotDeployed)
+	err = RollbackUpgrade(app, sc, models.Fuji, t.TempDir())
+	require.ErrorIs(err, ErrNotDeployed)
+
+	sc.Networks = map[string]models.NetworkData{models.Mainnet.String(): {}}
+	err = RollbackUpgrade(app, sc, models.Fuji, t.TempDir())
+	require.ErrorIs(err, ErrNotDeployed)
+}
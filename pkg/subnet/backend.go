@@ -0,0 +1,215 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	anrutils "github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"go.uber.org/zap"
+)
+
+const backendRestartSnapshotPrefix = "backend-restart"
+
+var errNoSnapshotToRestore = errors.New("no snapshot found to restore the network from")
+
+// CheckBackend checks the backend of the local network is answering and was started
+// by this version of the CLI. Otherwise, it restarts it, preserving its network
+func CheckBackend(app *application.Avalanche) error {
+	status, err := binutils.GetBackendStatus(app)
+	if err != nil {
+		if !errors.Is(err, binutils.ErrBackendNotRunning) {
+			return err
+		}
+		return StartBackend(app)
+	}
+	switch {
+	case !status.Responding:
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Backend controller (pid %d) is not responding, restarting it", status.Pid)))
+	case !status.VersionMatches(app):
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Backend controller was started by CLI version %q, restarting it with version %q",
+			status.Version, binutils.GetCLIVersion(app))))
+	default:
+		return nil
+	}
+	return RestartBackend(app)
+}
+
+// StartBackend starts the backend of the local network. If the previous backend
+// crashed leaving the network nodes running, they are stopped and the network is
+// restored from its last snapshot
+func StartBackend(app *application.Avalanche) error {
+	orphanNodes, err := binutils.GetNodeProcesses(app)
+	if err != nil {
+		return err
+	}
+	if len(orphanNodes) > 0 {
+		ux.Logger.PrintToUser(logging.Yellow.Wrap(fmt.Sprintf(
+			"Found %d nodes left running by a backend controller that stopped unexpectedly, stopping them", len(orphanNodes))))
+		if err := binutils.StopProcesses(orphanNodes); err != nil {
+			return err
+		}
+	}
+	if err := binutils.StartServerProcess(app); err != nil {
+		return err
+	}
+	if len(orphanNodes) == 0 {
+		return nil
+	}
+	snapshotName, err := getLastSnapshotName(app)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Restoring the network from snapshot %s...", snapshotName)
+	return loadNetworkSnapshot(app, snapshotName)
+}
+
+// RestartBackend restarts the backend of the local network. If it's answering, its
+// network is saved to a temporary snapshot, loaded back by the new backend and
+// then removed. Otherwise, the network is restored from its last snapshot
+func RestartBackend(app *application.Avalanche) error {
+	status, err := binutils.GetBackendStatus(app)
+	if err != nil && !errors.Is(err, binutils.ErrBackendNotRunning) {
+		return err
+	}
+	snapshotName := ""
+	if err == nil && status.Responding {
+		snapshotName, err = saveNetworkForRestart(app)
+		if err != nil {
+			return err
+		}
+	}
+	if err := binutils.StopServerProcess(app); err != nil {
+		return fmt.Errorf("failed stopping backend controller: %w", err)
+	}
+	if snapshotName == "" {
+		return StartBackend(app)
+	}
+	if err := binutils.StartServerProcess(app); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Restoring the network from snapshot %s...", snapshotName)
+	if err := loadNetworkSnapshot(app, snapshotName); err != nil {
+		return err
+	}
+	// the temporary snapshot is only kept to recover from a failed restart
+	if err := removeSnapshot(app, snapshotName); err != nil {
+		app.Log.Warn("failed removing temporary snapshot", zap.String("snapshot", snapshotName), zap.Error(err))
+	}
+	return nil
+}
+
+// removes the dir of snapshot [snapshotName]
+func removeSnapshot(app *application.Avalanche, snapshotName string) error {
+	snapshotPath, err := GetSnapshotPath(app, snapshotName)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(snapshotPath)
+}
+
+// saves the running network to a temporary snapshot, which stops it, and returns
+// the snapshot name. Returns an empty name if no network is running
+func saveNetworkForRestart(app *application.Avalanche) (string, error) {
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+	if _, err := cli.Status(ctx); err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return "", nil
+		}
+		return "", err
+	}
+	snapshotName := backendRestartSnapshotPrefix + constants.TmpSnapshotInfix + time.Now().Format("20060102150405")
+	ux.Logger.PrintToUser("Saving the network to snapshot %s...", snapshotName)
+	if err := SaveSnapshot(ctx, app, cli, snapshotName); err != nil {
+		return "", fmt.Errorf("failed saving network snapshot: %w", err)
+	}
+	return snapshotName, nil
+}
+
+// returns the most recently saved snapshot of the local network
+func getLastSnapshotName(app *application.Avalanche) (string, error) {
+	snapshots, err := GetSnapshots(app)
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) == 0 {
+		return "", errNoSnapshotToRestore
+	}
+	last := snapshots[0]
+	for _, snapshot := range snapshots[1:] {
+		if snapshot.ModTime.After(last.ModTime) {
+			last = snapshot
+		}
+	}
+	return last.Name, nil
+}
+
+// GetReloadSnapshotOpts returns the options to load back a snapshot of the running
+// local network: a new data dir under its run dir, its plugin dir and the global
+// node config, reassigning the ports already in use
+func GetReloadSnapshotOpts(app *application.Avalanche) ([]client.OpOption, error) {
+	outputDir, err := anrutils.MkDirWithTimestamp(filepath.Join(app.GetRunDir(), "restart"))
+	if err != nil {
+		return nil, err
+	}
+	loadSnapshotOpts := []client.OpOption{
+		client.WithRootDataDir(outputDir),
+		client.WithReassignPortsIfUsed(true),
+		client.WithPluginDir(app.GetPluginsDir()),
+	}
+	configStr, err := app.Conf.LoadNodeConfig()
+	if err != nil {
+		return nil, err
+	}
+	if configStr != "" {
+		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
+	}
+	return loadSnapshotOpts, nil
+}
+
+// loads [snapshotName] with the binaries it was saved with, and waits for
+// the network to be healthy
+func loadNetworkSnapshot(app *application.Avalanche, snapshotName string) error {
+	cli, err := binutils.NewGRPCClient(app)
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	ctx := binutils.GetAsyncContext()
+
+	loadSnapshotOpts, err := GetReloadSnapshotOpts(app)
+	if err != nil {
+		return err
+	}
+	if _, err := cli.LoadSnapshot(ctx, snapshotName, loadSnapshotOpts...); err != nil {
+		return fmt.Errorf("failed restoring network from snapshot %s: %w", snapshotName, err)
+	}
+	clusterInfo, err := WaitForHealthy(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed waiting for network to become healthy: %w", err)
+	}
+	if err := UpdateLocalAPIEndpoint(app, clusterInfo); err != nil {
+		app.Log.Warn("failed recording local network API endpoint", zap.Error(err))
+	}
+	UpdateMetricsConfig(app, clusterInfo)
+	ux.Logger.PrintToUser("Network restored")
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+)
+
+var ErrNotDeployedLocally = errors.New("subnet is not deployed to the local network")
+
+// RemoveLocalDeployment removes the local network entry of the sidecar of [subnetName],
+// along with the VM binary installed for it in the plugin dir, if no other local
+// deployment nor snapshot of the local network uses it, and the VM binary kept for
+// rolling back local VM upgrades. Returns the number of bytes reclaimed
+func RemoveLocalDeployment(app *application.Avalanche, subnetName string) (int64, error) {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	if _, ok := sc.Networks[app.GetNetworkKey(models.Local)]; !ok {
+		return 0, fmt.Errorf("%w: %s", ErrNotDeployedLocally, subnetName)
+	}
+	vmID, err := sc.GetVMID()
+	if err != nil {
+		return 0, err
+	}
+
+	delete(sc.Networks, app.GetNetworkKey(models.Local))
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return 0, err
+	}
+
+	var reclaimed int64
+	sharedVM, err := isVMDeployedLocally(app, vmID)
+	if err != nil {
+		return 0, err
+	}
+	// loading a snapshot whose chains miss their VM binary fails
+	snapshotNames, err := getSnapshotsUsingVM(app, vmID)
+	if err != nil {
+		return 0, err
+	}
+	if len(snapshotNames) > 0 {
+		ux.Logger.PrintToUser("Keeping the VM binary of subnet %s, used by snapshots %s", subnetName, strings.Join(snapshotNames, ", "))
+	}
+	if !sharedVM && len(snapshotNames) == 0 {
+		size, err := removePath(filepath.Join(app.GetPluginsDir(), vmID))
+		if err != nil {
+			return reclaimed, err
+		}
+		reclaimed += size
+	}
+	size, err := removePath(app.GetVMBackupPath(subnetName, app.GetNetworkKey(models.Local)))
+	if err != nil {
+		return reclaimed, err
+	}
+	return reclaimed + size, nil
+}
+
+// returns true if a subnet deployed to the local network uses [vmID]
+func isVMDeployedLocally(app *application.Avalanche, vmID string) (bool, error) {
+	sidecarNames, err := app.GetSidecarNames()
+	if err != nil {
+		return false, err
+	}
+	for _, sidecarName := range sidecarNames {
+		sc, err := app.LoadSidecar(sidecarName)
+		if err != nil {
+			continue
+		}
+		if _, ok := sc.Networks[app.GetNetworkKey(models.Local)]; !ok {
+			continue
+		}
+		if otherVMID, err := sc.GetVMID(); err == nil && otherVMID == vmID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// returns the names of the snapshots of the local network with a chain using [vmID].
+// Snapshots saved without metadata are not considered
+func getSnapshotsUsingVM(app *application.Avalanche, vmID string) ([]string, error) {
+	snapshots, err := GetSnapshots(app)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, snapshot := range snapshots {
+		if snapshot.Metadata == nil {
+			continue
+		}
+		for _, chain := range snapshot.Metadata.Chains {
+			if chain.VMID == vmID {
+				names = append(names, snapshot.Name)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// RemoveOldRuns removes the dirs of the run dir of the local network whose files
+// were all last modified before [cutoff], skipping the ones holding any of the
+// [inUse] paths. Returns the removed dirs and the number of bytes reclaimed
+func RemoveOldRuns(app *application.Avalanche, cutoff time.Time, inUse []string) ([]string, int64, error) {
+	runDir := app.GetRunDir()
+	entries, err := os.ReadDir(runDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	removed := []string{}
+	var reclaimed int64
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(runDir, entry.Name())
+		if holdsAny(path, inUse) {
+			continue
+		}
+		modTime, err := latestModTime(path)
+		if err != nil {
+			return removed, reclaimed, err
+		}
+		if !modTime.Before(cutoff) {
+			continue
+		}
+		size, err := removePath(path)
+		if err != nil {
+			return removed, reclaimed, err
+		}
+		removed = append(removed, path)
+		reclaimed += size
+	}
+	return removed, reclaimed, nil
+}
+
+// RemoveOldLogs removes the rotated log files of the CLI last modified before
+// [cutoff]. The file currently logged to is kept.
+// Returns the removed files and the number of bytes reclaimed
+func RemoveOldLogs(app *application.Avalanche, cutoff time.Time) ([]string, int64, error) {
+	logDir := filepath.Join(app.GetBaseDir(), constants.LogDir)
+	entries, err := os.ReadDir(logDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	removed := []string{}
+	var reclaimed int64
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == constants.CLILogName+".log" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return removed, reclaimed, err
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		path := filepath.Join(logDir, entry.Name())
+		if err := os.Remove(path); err != nil {
+			return removed, reclaimed, err
+		}
+		removed = append(removed, path)
+		reclaimed += info.Size()
+	}
+	return removed, reclaimed, nil
+}
+
+// removes [path] if it exists, returning its size
+func removePath(path string) (int64, error) {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	size, err := utils.DirSize(path)
+	if err != nil {
+		return 0, err
+	}
+	return size, os.RemoveAll(path)
+}
+
+func holdsAny(dir string, paths []string) bool {
+	for _, path := range paths {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the latest modification time of [dir] and the files under it
+func latestModTime(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
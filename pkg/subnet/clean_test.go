@@ -0,0 +1,103 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestRemoveLocalDeployment(t *testing.T) {
+	require := setupTest(t)
+
+	app := &application.Avalanche{}
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	sc := models.Sidecar{
+		Name: testChainName,
+		VM:   models.SubnetEvm,
+		Networks: map[string]models.NetworkData{
+			// recorded before each local network had its own key
+			models.Local.String(): {SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()},
+			models.Fuji.String():  {SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()},
+		},
+	}
+	require.NoError(app.CreateSidecar(&sc))
+	require.NoError(os.MkdirAll(app.GetPluginsDir(), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(app.GetPluginsDir(), testVMID), []byte("vm"), constants.DefaultPerms755))
+
+	reclaimed, err := RemoveLocalDeployment(app, testChainName)
+	require.NoError(err)
+	require.Equal(int64(len("vm")), reclaimed)
+	require.NoFileExists(filepath.Join(app.GetPluginsDir(), testVMID))
+
+	sc, err = app.LoadSidecar(testChainName)
+	require.NoError(err)
+	require.NotContains(sc.Networks, models.Local.String())
+	require.NotContains(sc.Networks, app.GetNetworkKey(models.Local))
+	require.Contains(sc.Networks, models.Fuji.String())
+
+	_, err = RemoveLocalDeployment(app, testChainName)
+	require.ErrorIs(err, ErrNotDeployedLocally)
+
+	// the VM binary is kept while a snapshot of the local network has a chain using it
+	sc.Networks = map[string]models.NetworkData{
+		app.GetNetworkKey(models.Local): {SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()},
+	}
+	require.NoError(app.UpdateSidecar(&sc))
+	require.NoError(os.WriteFile(filepath.Join(app.GetPluginsDir(), testVMID), []byte("vm"), constants.DefaultPerms755))
+	snapshotPath, err := GetSnapshotPath(app, "with-chain")
+	require.NoError(err)
+	require.NoError(os.MkdirAll(snapshotPath, constants.DefaultPerms755))
+	metadataBytes, err := json.Marshal(SnapshotMetadata{Chains: []SnapshotChain{{ChainName: testChainName, VMID: testVMID}}})
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, snapshotMetadataFileName), metadataBytes, WriteReadReadPerms))
+
+	reclaimed, err = RemoveLocalDeployment(app, testChainName)
+	require.NoError(err)
+	require.Zero(reclaimed)
+	require.FileExists(filepath.Join(app.GetPluginsDir(), testVMID))
+}
+
+func TestRemoveOldRuns(t *testing.T) {
+	require := setupTest(t)
+
+	app := &application.Avalanche{}
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	old := time.Now().Add(-48 * time.Hour)
+	runDirs := map[string]time.Time{
+		"network_old":   old,
+		"server_in_use": old,
+		"network_new":   time.Now(),
+	}
+	for name, modTime := range runDirs {
+		dir := filepath.Join(app.GetRunDir(), name)
+		require.NoError(os.MkdirAll(dir, constants.DefaultPerms755))
+		file := filepath.Join(dir, "output")
+		require.NoError(os.WriteFile(file, []byte("output"), WriteReadReadPerms))
+		require.NoError(os.Chtimes(file, modTime, modTime))
+		require.NoError(os.Chtimes(dir, modTime, modTime))
+	}
+	require.NoError(os.WriteFile(app.GetRunFile(), []byte("{}"), WriteReadReadPerms))
+
+	inUse := []string{filepath.Join(app.GetRunDir(), "server_in_use", "output")}
+	removed, reclaimed, err := RemoveOldRuns(app, time.Now().Add(-24*time.Hour), inUse)
+	require.NoError(err)
+	require.Equal([]string{filepath.Join(app.GetRunDir(), "network_old")}, removed)
+	require.Equal(int64(len("output")), reclaimed)
+	require.DirExists(filepath.Join(app.GetRunDir(), "server_in_use"))
+	require.DirExists(filepath.Join(app.GetRunDir(), "network_new"))
+	require.FileExists(app.GetRunFile())
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/key"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -16,7 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-func GetOwners(network models.Network, subnetID ids.ID) ([]string, uint32, error) {
+func GetOwners(app *application.Avalanche, network models.Network, subnetID ids.ID) ([]string, uint32, error) {
 	var api string
 	switch network {
 	case models.Fuji:
@@ -24,11 +25,16 @@ func GetOwners(network models.Network, subnetID ids.ID) ([]string, uint32, error
 	case models.Mainnet:
 		api = constants.MainnetAPIEndpoint
 	case models.Local:
-		api = constants.LocalAPIEndpoint
+		api = app.GetLocalAPIEndpoint()
 	default:
 		return nil, 0, fmt.Errorf("network not supported")
 	}
-	pClient := platformvm.NewClient(api)
+	return GetOwnersFromClient(platformvm.NewClient(api), network, subnetID)
+}
+
+// GetOwnersFromClient returns the control keys and threshold of [subnetID] on
+// [network], as given by the P-Chain API client [pClient]
+func GetOwnersFromClient(pClient platformvm.Client, network models.Network, subnetID ids.ID) ([]string, uint32, error) {
 	ctx := context.Background()
 	txBytes, err := pClient.GetTx(ctx, subnetID)
 	if err != nil {
@@ -7,9 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
@@ -38,6 +36,10 @@ const (
 	WriteReadReadPerms = 0o644
 )
 
+// ErrAvalancheGoPathMismatch is returned when deploying with a locally built avalanchego
+// to a local network already running another binary
+var ErrAvalancheGoPathMismatch = errors.New("the local network runs another avalanchego binary")
+
 type LocalDeployer struct {
 	procChecker        binutils.ProcessChecker
 	binChecker         binutils.BinaryChecker
@@ -46,11 +48,13 @@ type LocalDeployer struct {
 	app                *application.Avalanche
 	backendStartedHere bool
 	setDefaultSnapshot setDefaultSnapshotFunc
+	checkBackend       checkBackendFunc
 	avagoVersion       string
+	avagoPath          string
 	vmBin              string
 }
 
-func NewLocalDeployer(app *application.Avalanche, avagoVersion string, vmBin string) *LocalDeployer {
+func NewLocalDeployer(app *application.Avalanche, avagoVersion string, avagoPath string, vmBin string) *LocalDeployer {
 	return &LocalDeployer{
 		procChecker:        binutils.NewProcessChecker(),
 		binChecker:         binutils.NewBinaryChecker(),
@@ -58,19 +62,28 @@ func NewLocalDeployer(app *application.Avalanche, avagoVersion string, vmBin str
 		binaryDownloader:   binutils.NewPluginBinaryDownloader(app),
 		app:                app,
 		setDefaultSnapshot: SetDefaultSnapshot,
+		checkBackend:       CheckBackend,
 		avagoVersion:       avagoVersion,
+		avagoPath:          avagoPath,
 		vmBin:              vmBin,
 	}
 }
 
-type getGRPCClientFunc func() (client.Client, error)
+type getGRPCClientFunc func(*application.Avalanche) (client.Client, error)
+
+type setDefaultSnapshotFunc func(*application.Avalanche, bool) error
 
-type setDefaultSnapshotFunc func(string, bool) error
+type checkBackendFunc func(*application.Avalanche) error
 
 // DeployToLocalNetwork does the heavy lifting:
 // * it checks the gRPC is running, if not, it starts it
 // * kicks off the actual deployment
 func (d *LocalDeployer) DeployToLocalNetwork(chain string, chainGenesis []byte, genesisPath string) (ids.ID, ids.ID, error) {
+	lock, err := d.app.LockLocalNetwork()
+	if err != nil {
+		return ids.Empty, ids.Empty, err
+	}
+	defer lock.Release()
 	if err := d.StartServer(); err != nil {
 		return ids.Empty, ids.Empty, err
 	}
@@ -82,13 +95,14 @@ func (d *LocalDeployer) StartServer() error {
 	if err != nil {
 		return fmt.Errorf("failed querying if server process is running: %w", err)
 	}
-	if !isRunning {
-		d.app.Log.Debug("gRPC server is not running")
-		if err := binutils.StartServerProcess(d.app); err != nil {
-			return fmt.Errorf("failed starting gRPC server process: %w", err)
-		}
-		d.backendStartedHere = true
+	if isRunning {
+		return d.checkBackend(d.app)
+	}
+	d.app.Log.Debug("gRPC server is not running")
+	if err := StartBackend(d.app); err != nil {
+		return fmt.Errorf("failed starting gRPC server process: %w", err)
 	}
+	d.backendStartedHere = true
 	return nil
 }
 
@@ -115,7 +129,7 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 		return ids.Empty, ids.Empty, err
 	}
 
-	cli, err := d.getClientFunc()
+	cli, err := d.getClientFunc(d.app)
 	if err != nil {
 		return ids.Empty, ids.Empty, fmt.Errorf("error creating gRPC Client: %w", err)
 	}
@@ -148,6 +162,13 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 	}
 	d.app.Log.Debug("this VM will get ID", zap.String("vm-id", chainVMID.String()))
 
+	// the avalanchego binary is only chosen when starting the network
+	if networkBooted && d.avagoPath != "" {
+		if err := checkAvalancheGoPath(clusterInfo, d.avagoPath); err != nil {
+			return ids.Empty, ids.Empty, err
+		}
+	}
+
 	if alreadyDeployed(chainVMID, clusterInfo) {
 		ux.Logger.PrintToUser("Subnet %s has already been deployed", chain)
 		return ids.Empty, ids.Empty, nil
@@ -163,19 +184,10 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 	if err != nil {
 		return ids.Empty, ids.Empty, fmt.Errorf("failed to query network health: %w", err)
 	}
-	subnetIDs := clusterInfo.Subnets
-	numBlockchains := len(clusterInfo.CustomChains)
-
-	// in order to make subnet deploy faster, a set of validated subnet IDs is preloaded
-	// in the bootstrap snapshot
-	// we select one to be used for creating the next blockchain, for that we use the
-	// number of currently created blockchains as the index to select the next subnet ID,
-	// so we get incremental selection
-	sort.Strings(subnetIDs)
-	if len(subnetIDs) == 0 {
-		return ids.Empty, ids.Empty, errors.New("the network has not preloaded subnet IDs")
+	subnetIDStr, err := selectSubnetID(ctx, cli, clusterInfo)
+	if err != nil {
+		return ids.Empty, ids.Empty, err
 	}
-	subnetIDStr := subnetIDs[numBlockchains%len(subnetIDs)]
 
 	// if a chainConfig has been configured
 	var (
@@ -236,6 +248,11 @@ func (d *LocalDeployer) doDeploy(chain string, chainGenesis []byte, genesisPath
 		return ids.Empty, ids.Empty, fmt.Errorf("failed to query network health: %w", err)
 	}
 
+	if err := UpdateLocalAPIEndpoint(d.app, clusterInfo); err != nil {
+		d.app.Log.Warn("failed recording local network API endpoint", zap.Error(err))
+	}
+	UpdateMetricsConfig(d.app, clusterInfo)
+
 	endpoint := GetFirstEndpoint(clusterInfo, chain)
 
 	fmt.Println()
@@ -312,20 +329,23 @@ func (d *LocalDeployer) printExtraEvmInfo(chain string, chainGenesis []byte) err
 // * sets up default snapshot if not installed
 // * checks if avalanchego is installed in the local binary path
 // * if not, it downloads it and installs it (os - and archive dependent)
-// * returns the location of the avalanchego path
+// * returns the location of the avalanchego path, or the locally built one if given
 func (d *LocalDeployer) SetupLocalEnv() (string, error) {
-	err := d.setDefaultSnapshot(d.app.GetSnapshotsDir(), false)
+	err := d.setDefaultSnapshot(d.app, false)
 	if err != nil {
 		return "", fmt.Errorf("failed setting up snapshots: %w", err)
 	}
 
-	avagoDir, err := d.setupLocalEnv()
-	if err != nil {
-		return "", fmt.Errorf("failed setting up local environment: %w", err)
+	avalancheGoBinPath := d.avagoPath
+	if avalancheGoBinPath == "" {
+		avagoDir, err := d.setupLocalEnv()
+		if err != nil {
+			return "", fmt.Errorf("failed setting up local environment: %w", err)
+		}
+		avalancheGoBinPath = filepath.Join(avagoDir, "avalanchego")
 	}
 
 	pluginDir := d.app.GetPluginsDir()
-	avalancheGoBinPath := filepath.Join(avagoDir, "avalanchego")
 
 	if err := os.MkdirAll(pluginDir, constants.DefaultPerms755); err != nil {
 		return "", fmt.Errorf("could not create pluginDir %s", pluginDir)
@@ -380,6 +400,22 @@ func GetFirstEndpoint(clusterInfo *rpcpb.ClusterInfo, chain string) string {
 	return endpoint
 }
 
+// UpdateLocalAPIEndpoint records the API endpoint of the first node of [clusterInfo]
+// as the one of the local network targeted by [app], as ports may have been
+// reassigned on (re)start
+func UpdateLocalAPIEndpoint(app *application.Avalanche, clusterInfo *rpcpb.ClusterInfo) error {
+	nodeNames := clusterInfo.GetNodeNames()
+	if len(nodeNames) == 0 {
+		return nil
+	}
+	sort.Strings(nodeNames)
+	nodeInfo, ok := clusterInfo.GetNodeInfos()[nodeNames[0]]
+	if !ok || nodeInfo.GetUri() == "" {
+		return nil
+	}
+	return app.SetLocalAPIEndpoint(nodeInfo.GetUri())
+}
+
 // HasEndpoints returns true if cluster info contains custom blockchains
 func HasEndpoints(clusterInfo *rpcpb.ClusterInfo) bool {
 	return len(clusterInfo.CustomChains) > 0
@@ -397,6 +433,42 @@ func alreadyDeployed(chainVMID ids.ID, clusterInfo *rpcpb.ClusterInfo) bool {
 	return false
 }
 
+// GetDeployedAvalancheGoPaths returns the locally built avalanchego binaries the subnets
+// deployed to the target local network were deployed with, with the names of those subnets
+func GetDeployedAvalancheGoPaths(app *application.Avalanche) (map[string][]string, error) {
+	sidecarNames, err := app.GetSidecarNames()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string][]string{}, nil
+		}
+		return nil, err
+	}
+	networkKey := app.GetNetworkKey(models.Local)
+	avagoPaths := map[string][]string{}
+	for _, sidecarName := range sidecarNames {
+		sc, err := app.LoadSidecar(sidecarName)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := sc.Networks[networkKey]; !ok || sc.AvalancheGoPath == "" {
+			continue
+		}
+		avagoPaths[sc.AvalancheGoPath] = append(avagoPaths[sc.AvalancheGoPath], sc.Name)
+	}
+	return avagoPaths, nil
+}
+
+// checks the nodes of the running network in [clusterInfo] run the avalanchego at [avagoPath]
+func checkAvalancheGoPath(clusterInfo *rpcpb.ClusterInfo, avagoPath string) error {
+	for nodeName, nodeInfo := range clusterInfo.GetNodeInfos() {
+		if filepath.Clean(nodeInfo.GetExecPath()) != filepath.Clean(avagoPath) {
+			return fmt.Errorf("%w: node %s runs %s instead of %s. Stop the network with avalanche network stop "+
+				"to restart it with the given binary", ErrAvalancheGoPathMismatch, nodeName, nodeInfo.GetExecPath(), avagoPath)
+		}
+	}
+	return nil
+}
+
 // get list of all needed plugins and install them
 func (d *LocalDeployer) installPlugin(
 	vmID ids.ID,
@@ -412,16 +484,8 @@ func (d *LocalDeployer) removeInstalledPlugin(
 	return d.binaryDownloader.RemoveVM(vmID.String())
 }
 
-func getExpectedDefaultSnapshotSHA256Sum() (string, error) {
-	resp, err := http.Get(constants.BootstrapSnapshotSHA256URL)
-	if err != nil {
-		return "", fmt.Errorf("failed downloading sha256 sums: %w", err)
-	}
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed downloading sha256 sums: unexpected http status code: %d", resp.StatusCode)
-	}
-	defer resp.Body.Close()
-	sha256FileBytes, err := io.ReadAll(resp.Body)
+func getExpectedDefaultSnapshotSHA256Sum(app *application.Avalanche) (string, error) {
+	sha256FileBytes, err := app.Downloader.Download(constants.BootstrapSnapshotSHA256URL)
 	if err != nil {
 		return "", fmt.Errorf("failed downloading sha256 sums: %w", err)
 	}
@@ -434,7 +498,8 @@ func getExpectedDefaultSnapshotSHA256Sum() (string, error) {
 
 // Initialize default snapshot with bootstrap snapshot archive
 // If force flag is set to true, overwrite the default snapshot if it exists
-func SetDefaultSnapshot(snapshotsDir string, force bool) error {
+func SetDefaultSnapshot(app *application.Avalanche, force bool) error {
+	snapshotsDir := app.GetSnapshotsDir()
 	bootstrapSnapshotArchivePath := filepath.Join(snapshotsDir, constants.BootstrapSnapshotArchiveName)
 	// will download either if file not exists or if sha256 sum is not the same
 	downloadSnapshot := false
@@ -445,23 +510,18 @@ func SetDefaultSnapshot(snapshotsDir string, force bool) error {
 		if err != nil {
 			return err
 		}
-		expectedSum, err := getExpectedDefaultSnapshotSHA256Sum()
-		if err != nil {
+		expectedSum, err := getExpectedDefaultSnapshotSHA256Sum(app)
+		switch {
+		case errors.Is(err, application.ErrOffline):
+			app.Log.Debug("skipping bootstrap snapshot update check in offline mode")
+		case err != nil:
 			ux.Logger.PrintToUser("Warning: failure verifying that the local snapshot is the latest one: %s", err)
-		} else if gotSum != expectedSum {
+		case gotSum != expectedSum:
 			downloadSnapshot = true
 		}
 	}
 	if downloadSnapshot {
-		resp, err := http.Get(constants.BootstrapSnapshotURL)
-		if err != nil {
-			return fmt.Errorf("failed downloading bootstrap snapshot: %w", err)
-		}
-		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("failed downloading bootstrap snapshot: unexpected http status code: %d", resp.StatusCode)
-		}
-		defer resp.Body.Close()
-		bootstrapSnapshotBytes, err := io.ReadAll(resp.Body)
+		bootstrapSnapshotBytes, err := app.Downloader.Download(constants.BootstrapSnapshotURL)
 		if err != nil {
 			return fmt.Errorf("failed downloading bootstrap snapshot: %w", err)
 		}
@@ -523,12 +583,79 @@ func (d *LocalDeployer) startNetwork(
 	return nil
 }
 
+// StartFreshNetwork starts a local network with the given [topology], generating
+// a new genesis instead of loading the bootstrap snapshot. The network has no
+// preloaded subnets, so they are created on deploy
+func (d *LocalDeployer) StartFreshNetwork(
+	ctx context.Context,
+	cli client.Client,
+	avalancheGoBinPath string,
+	runDir string,
+	topology LocalNetworkTopology,
+) error {
+	customNodeConfigs, err := topology.CustomNodeConfigs()
+	if err != nil {
+		return err
+	}
+	startOpts := []client.OpOption{
+		client.WithNumNodes(topology.NumNodes),
+		client.WithRootDataDir(runDir),
+		client.WithReassignPortsIfUsed(true),
+		client.WithPluginDir(d.app.GetPluginsDir()),
+		// the global node config is already merged into every custom config
+		client.WithCustomNodeConfigs(customNodeConfigs),
+	}
+
+	pp, err := cli.Start(ctx, avalancheGoBinPath, startOpts...)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Node log path: %s/node<i>/logs", pp.ClusterInfo.RootDataDir)
+	ux.Logger.PrintToUser("Starting network with %d nodes...", topology.NumNodes)
+	return nil
+}
+
+// selectSubnetID returns the ID of a validated subnet to create the next blockchain on.
+// In order to make subnet deploy faster, a set of validated subnet IDs is preloaded
+// in the bootstrap snapshot, so the first one without blockchains is used.
+// If there is none (all used, or a network not started from the bootstrap snapshot),
+// a new subnet is created
+func selectSubnetID(ctx context.Context, cli client.Client, clusterInfo *rpcpb.ClusterInfo) (string, error) {
+	usedSubnetIDs := map[string]bool{}
+	for _, chainInfo := range clusterInfo.GetCustomChains() {
+		usedSubnetIDs[chainInfo.GetSubnetId()] = true
+	}
+	subnetIDs := clusterInfo.GetSubnets()
+	sort.Strings(subnetIDs)
+	for _, subnetID := range subnetIDs {
+		if !usedSubnetIDs[subnetID] {
+			return subnetID, nil
+		}
+	}
+
+	ux.Logger.PrintToUser("No preloaded subnet available, creating a new one...")
+	resp, err := cli.CreateSubnets(ctx, client.WithNumSubnets(1))
+	if err != nil {
+		return "", fmt.Errorf("failed to create subnet: %w", err)
+	}
+	existingSubnetIDs := map[string]bool{}
+	for _, subnetID := range subnetIDs {
+		existingSubnetIDs[subnetID] = true
+	}
+	for _, subnetID := range resp.GetClusterInfo().GetSubnets() {
+		if !existingSubnetIDs[subnetID] {
+			return subnetID, nil
+		}
+	}
+	return "", errors.New("subnet creation succeeded but its ID was not found on the network")
+}
+
 // Returns an error if the server cannot be contacted. You may want to ignore this error.
-func GetLocallyDeployedSubnets() (map[string]struct{}, error) {
+func GetLocallyDeployedSubnets(app *application.Avalanche) (map[string]struct{}, error) {
 	deployedNames := map[string]struct{}{}
 	// if the server can not be contacted, or there is a problem with the query,
 	// DO NOT FAIL, just print No for deployed status
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(app)
 	if err != nil {
 		return nil, err
 	}
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/config"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-network-runner/client"
@@ -116,6 +117,7 @@ func TestDeployToLocal(t *testing.T) {
 		binaryDownloader:   binDownloader,
 		app:                app,
 		setDefaultSnapshot: fakeSetDefaultSnapshot,
+		checkBackend:       fakeCheckBackend,
 		avagoVersion:       avagoVersion,
 	}
 
@@ -160,7 +162,48 @@ func TestGetLatestAvagoVersion(t *testing.T) {
 	require.Equal(v, testVersion)
 }
 
-func getTestClientFunc() (client.Client, error) {
+func TestCheckAvalancheGoPath(t *testing.T) {
+	require := setupTest(t)
+
+	clusterInfo := proto.Clone(fakeWaitForHealthyResponse.ClusterInfo).(*rpcpb.ClusterInfo)
+	for _, nodeInfo := range clusterInfo.NodeInfos {
+		nodeInfo.ExecPath = "/build/avalanchego"
+	}
+	require.NoError(checkAvalancheGoPath(clusterInfo, "/build/../build/avalanchego"))
+
+	clusterInfo.NodeInfos["testNode2"].ExecPath = "/bin/avalanchego-v1.9.4/avalanchego"
+	err := checkAvalancheGoPath(clusterInfo, "/build/avalanchego")
+	require.ErrorIs(err, ErrAvalancheGoPathMismatch)
+	require.ErrorContains(err, "testNode2")
+}
+
+func TestGetDeployedAvalancheGoPaths(t *testing.T) {
+	require := setupTest(t)
+
+	app := &application.Avalanche{}
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	localNetworks := map[string]models.NetworkData{
+		app.GetNetworkKey(models.Local): {SubnetID: ids.GenerateTestID(), BlockchainID: ids.GenerateTestID()},
+	}
+	for _, sc := range []models.Sidecar{
+		{Name: "subnet1", VM: models.SubnetEvm, AvalancheGoPath: "/build/avalanchego", Networks: localNetworks},
+		{Name: "subnet2", VM: models.SubnetEvm, AvalancheGoPath: "/build/avalanchego", Networks: localNetworks},
+		// released avalanchego
+		{Name: "subnet3", VM: models.SubnetEvm, Networks: localNetworks},
+		// not deployed to the local network anymore
+		{Name: "subnet4", VM: models.SubnetEvm, AvalancheGoPath: "/other/avalanchego"},
+	} {
+		sc := sc
+		require.NoError(app.CreateSidecar(&sc))
+	}
+
+	avagoPaths, err := GetDeployedAvalancheGoPaths(app)
+	require.NoError(err)
+	require.Equal(map[string][]string{"/build/avalanchego": {"subnet1", "subnet2"}}, avagoPaths)
+}
+
+func getTestClientFunc(*application.Avalanche) (client.Client, error) {
 	c := &mocks.Client{}
 	fakeLoadSnapshotResponse := &rpcpb.LoadSnapshotResponse{}
 	fakeSaveSnapshotResponse := &rpcpb.SaveSnapshotResponse{}
@@ -187,6 +230,10 @@ func getTestClientFunc() (client.Client, error) {
 	return c, nil
 }
 
-func fakeSetDefaultSnapshot(string, bool) error {
+func fakeSetDefaultSnapshot(*application.Avalanche, bool) error {
+	return nil
+}
+
+func fakeCheckBackend(*application.Avalanche) error {
 	return nil
 }
@@ -0,0 +1,106 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/metrics"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"go.uber.org/zap"
+)
+
+// WriteMetricsConfig writes a Prometheus config scraping the nodes of [clusterInfo]
+// and a Grafana dashboard for its chains to the metrics dir of the local network
+func WriteMetricsConfig(app *application.Avalanche, clusterInfo *rpcpb.ClusterInfo) error {
+	metricsDir := app.GetMetricsDir()
+	if err := os.MkdirAll(metricsDir, constants.DefaultPerms755); err != nil {
+		return err
+	}
+
+	targetsPath := filepath.Join(metricsDir, metrics.PrometheusTargetsFileName)
+	configBytes, err := metrics.PrometheusConfig("avalanche-"+app.GetLocalNetworkName(), targetsPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(metricsDir, metrics.PrometheusConfigFileName), configBytes, WriteReadReadPerms); err != nil {
+		return err
+	}
+
+	nodeNames := clusterInfo.GetNodeNames()
+	sort.Strings(nodeNames)
+	targets := []metrics.Target{}
+	for _, nodeName := range nodeNames {
+		nodeInfo, ok := clusterInfo.GetNodeInfos()[nodeName]
+		if !ok {
+			continue
+		}
+		targets = append(targets, metrics.Target{
+			NodeName: nodeName,
+			NodeID:   nodeInfo.GetId(),
+			URI:      nodeInfo.GetUri(),
+		})
+	}
+	targetsBytes, err := metrics.PrometheusTargets(targets)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(targetsPath, targetsBytes, WriteReadReadPerms); err != nil {
+		return err
+	}
+
+	subnetNames := GetLocalSubnetNames(app)
+	chains := []metrics.Chain{}
+	for blockchainID, chainInfo := range clusterInfo.GetCustomChains() {
+		name, ok := subnetNames[blockchainID]
+		if !ok {
+			name = chainInfo.GetChainName()
+		}
+		chains = append(chains, metrics.Chain{Name: name, BlockchainID: blockchainID})
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return chains[i].Name < chains[j].Name
+	})
+	dashboardBytes, err := metrics.GrafanaDashboard("Avalanche local network "+app.GetLocalNetworkName(), chains)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(metricsDir, metrics.GrafanaDashboardFileName), dashboardBytes, WriteReadReadPerms)
+}
+
+// UpdateMetricsConfig rewrites the metrics config of the local network after its
+// nodes or chains changed, if it was written before with network metrics.
+// Failures are only logged, as metrics are not needed to use the network
+func UpdateMetricsConfig(app *application.Avalanche, clusterInfo *rpcpb.ClusterInfo) {
+	if _, err := os.Stat(app.GetMetricsDir()); err != nil {
+		return
+	}
+	if err := WriteMetricsConfig(app, clusterInfo); err != nil {
+		app.Log.Warn("failed updating metrics config", zap.Error(err))
+	}
+}
+
+// GetLocalSubnetNames returns the names of the subnets deployed to the
+// local network, by blockchain ID
+func GetLocalSubnetNames(app *application.Avalanche) map[string]string {
+	names := map[string]string{}
+	sidecarNames, err := app.GetSidecarNames()
+	if err != nil {
+		return names
+	}
+	for _, sidecarName := range sidecarNames {
+		sc, err := app.LoadSidecar(sidecarName)
+		if err != nil {
+			continue
+		}
+		if network, ok := sc.Networks[app.GetNetworkKey(models.Local)]; ok {
+			names[network.BlockchainID.String()] = sc.Name
+		}
+	}
+	return names
+}
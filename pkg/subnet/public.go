@@ -17,14 +17,22 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"go.uber.org/zap"
 )
 
-var ErrNoSubnetAuthKeysInWallet = errors.New("wallet does not contain subnet auth keys")
+var (
+	ErrNoSubnetAuthKeysInWallet = errors.New("wallet does not contain subnet auth keys")
+	ErrTxDropped                = errors.New("tx was dropped by the P-Chain")
+	ErrTxCommitTimeout          = errors.New("timeout waiting for tx to be committed")
+)
 
 type PublicDeployer struct {
 	LocalDeployer
@@ -36,7 +44,7 @@ type PublicDeployer struct {
 
 func NewPublicDeployer(app *application.Avalanche, usingLedger bool, kc keychain.Keychain, network models.Network) *PublicDeployer {
 	return &PublicDeployer{
-		LocalDeployer: *NewLocalDeployer(app, "", ""),
+		LocalDeployer: *NewLocalDeployer(app, "", "", ""),
 		app:           app,
 		usingLedger:   usingLedger,
 		kc:            kc,
@@ -105,6 +113,39 @@ func (d *PublicDeployer) AddValidator(
 	return false, tx, nil
 }
 
+// adds [nodeID] as a validator of the primary network, staking [stakeAmount] from the
+// wallet, which is required before it can validate any other subnet
+func (d *PublicDeployer) AddPrimaryValidator(
+	nodeID ids.NodeID,
+	stakeAmount uint64,
+	startTime time.Time,
+	duration time.Duration,
+) (ids.ID, error) {
+	wallet, err := d.loadWallet()
+	if err != nil {
+		return ids.Empty, err
+	}
+	validator := &validator.Validator{
+		NodeID: nodeID,
+		Start:  uint64(startTime.Unix()),
+		End:    uint64(startTime.Add(duration).Unix()),
+		Wght:   stakeAmount,
+	}
+	rewardsOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     d.kc.Addresses().List(),
+	}
+	if d.usingLedger {
+		ux.Logger.PrintToUser("*** Please sign add primary validator hash on the ledger device *** ")
+	}
+	id, err := wallet.P().IssueAddValidatorTx(validator, rewardsOwner, reward.PercentDenominator)
+	if err != nil {
+		return ids.Empty, err
+	}
+	ux.Logger.PrintToUser("Transaction successful, transaction ID: %s", id)
+	return id, nil
+}
+
 // deploys the given [chain]
 // - verifies that the wallet is one of the subnet auth keys (so as to sign the CreateBlockchain tx)
 // - creates a subnet using the given [controlKeys] and [threshold] as subnet authentication parameters
@@ -171,14 +212,62 @@ func (d *PublicDeployer) Deploy(
 	return isFullySigned, subnetID, blockchainID, blockchainTx, nil
 }
 
+// issues the given fully signed [tx] to the P-Chain, and waits up to [timeout]
+// for the tx to be committed
+// - returns ErrTxDropped, with the reason given by the P-Chain, if the tx is dropped or aborted
+// - returns ErrTxCommitTimeout if the tx is not decided before [timeout]
 func (d *PublicDeployer) Commit(
 	tx *txs.Tx,
+	timeout time.Duration,
 ) (ids.ID, error) {
 	wallet, err := d.loadWallet()
 	if err != nil {
 		return ids.Empty, err
 	}
-	return wallet.P().IssueTx(tx)
+	txID, err := wallet.P().IssueTx(tx, common.WithAssumeDecided())
+	if err != nil {
+		return ids.Empty, err
+	}
+	ux.Logger.PrintToUser("Transaction %s issued, waiting for it to be committed...", txID)
+	return txID, d.waitForTxCommit(txID, timeout)
+}
+
+// polls the P-Chain tx status of [txID] until it is decided or [timeout] expires
+func (d *PublicDeployer) waitForTxCommit(txID ids.ID, timeout time.Duration) error {
+	api, err := d.app.GetAPIEndpoint(d.network)
+	if err != nil {
+		return err
+	}
+	pClient := platformvm.NewClient(api)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ticker := time.NewTicker(constants.TxStatusPollInterval)
+	defer ticker.Stop()
+	lastStatus := status.Unknown
+	for {
+		resp, err := pClient.GetTxStatus(ctx, txID)
+		switch {
+		case err == nil:
+			lastStatus = resp.Status
+			switch resp.Status {
+			case status.Committed:
+				return nil
+			case status.Dropped, status.Aborted:
+				if resp.Reason != "" {
+					return fmt.Errorf("%w: status %s, reason: %s", ErrTxDropped, resp.Status, resp.Reason)
+				}
+				return fmt.Errorf("%w: status %s", ErrTxDropped, resp.Status)
+			}
+		case ctx.Err() == nil:
+			// transient API failure, keep polling until timeout
+			d.app.Log.Debug("failure querying tx status", zap.Stringer("txID", txID), zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w %s after %s, last status: %s", ErrTxCommitTimeout, txID, timeout, lastStatus)
+		case <-ticker.C:
+		}
+	}
 }
 
 func (d *PublicDeployer) Sign(
@@ -206,6 +295,76 @@ func (d *PublicDeployer) Sign(
 	return nil
 }
 
+// rebuilds the given subnet auth [tx] with fresh UTXOs, keeping its semantic content
+//   - verifies that the wallet is one of the subnet auth keys (so as to sign the new tx)
+//   - creates a new unsigned tx of the same type and with the same parameters as [tx],
+//     using [subnetAuthKeysStrs] as subnet auth keys
+//   - signs the new tx with the wallet as the owner of fee outputs and one of the subnet auth keys
+//   - returns the new tx so that it can be later on signed by the rest of the subnet auth keys
+func (d *PublicDeployer) Rebuild(
+	tx *txs.Tx,
+	subnetAuthKeysStrs []string,
+	subnet ids.ID,
+) (*txs.Tx, error) {
+	wallet, err := d.loadWallet(subnet)
+	if err != nil {
+		return nil, err
+	}
+	subnetAuthKeys, err := address.ParseToIDs(subnetAuthKeysStrs)
+	if err != nil {
+		return nil, fmt.Errorf("failure parsing subnet auth keys: %w", err)
+	}
+	if ok := d.checkWalletHasSubnetAuthAddresses(subnetAuthKeys); !ok {
+		return nil, ErrNoSubnetAuthKeysInWallet
+	}
+	if d.usingLedger {
+		ux.Logger.PrintToUser("*** Please sign tx hash on the ledger device *** ")
+	}
+	options := d.getMultisigTxOptions(subnetAuthKeys)
+	builder := wallet.P().Builder()
+	var unsignedTx txs.UnsignedTx
+	switch oldTx := tx.Unsigned.(type) {
+	case *txs.CreateChainTx:
+		unsignedTx, err = builder.NewCreateChainTx(
+			oldTx.SubnetID,
+			oldTx.GenesisData,
+			oldTx.VMID,
+			oldTx.FxIDs,
+			oldTx.ChainName,
+			options...,
+		)
+	case *txs.AddSubnetValidatorTx:
+		validator := oldTx.Validator
+		unsignedTx, err = builder.NewAddSubnetValidatorTx(&validator, options...)
+	case *txs.RemoveSubnetValidatorTx:
+		unsignedTx, err = builder.NewRemoveSubnetValidatorTx(oldTx.NodeID, oldTx.Subnet, options...)
+	case *txs.TransformSubnetTx:
+		unsignedTx, err = builder.NewTransformSubnetTx(
+			oldTx.Subnet,
+			oldTx.AssetID,
+			oldTx.InitialSupply,
+			oldTx.MaximumSupply,
+			oldTx.MinConsumptionRate,
+			oldTx.MaxConsumptionRate,
+			oldTx.MinValidatorStake,
+			oldTx.MaxValidatorStake,
+			time.Duration(oldTx.MinStakeDuration)*time.Second,
+			time.Duration(oldTx.MaxStakeDuration)*time.Second,
+			oldTx.MinDelegationFee,
+			oldTx.MinDelegatorStake,
+			oldTx.MaxValidatorWeightFactor,
+			oldTx.UptimeRequirement,
+			options...,
+		)
+	default:
+		return nil, fmt.Errorf("unexpected unsigned tx type %T", tx.Unsigned)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d.createTx(unsignedTx, wallet)
+}
+
 func (d *PublicDeployer) loadWallet(preloadTxs ...ids.ID) (primary.Wallet, error) {
 	ctx := context.Background()
 
@@ -217,7 +376,7 @@ func (d *PublicDeployer) loadWallet(preloadTxs ...ids.ID) (primary.Wallet, error
 		api = constants.MainnetAPIEndpoint
 	case models.Local:
 		// used for E2E testing of public related paths
-		api = constants.LocalAPIEndpoint
+		api = d.app.GetLocalAPIEndpoint()
 	default:
 		return nil, fmt.Errorf("unsupported public network")
 	}
@@ -285,12 +444,7 @@ func (d *PublicDeployer) createBlockchainTx(
 	if err != nil {
 		return nil, err
 	}
-	tx := txs.Tx{Unsigned: unsignedTx}
-	// sign with current wallet
-	if err := wallet.P().Signer().Sign(context.Background(), &tx); err != nil {
-		return nil, err
-	}
-	return &tx, nil
+	return d.createTx(unsignedTx, wallet)
 }
 
 func (d *PublicDeployer) createAddSubnetValidatorTx(
@@ -304,6 +458,16 @@ func (d *PublicDeployer) createAddSubnetValidatorTx(
 	if err != nil {
 		return nil, err
 	}
+	return d.createTx(unsignedTx, wallet)
+}
+
+// creates a tx from the given unsigned subnet auth tx, and signs it with the
+// wallet keys, both for fee outputs and subnet auth, so that it can be later
+// on signed by the rest of the subnet auth keys
+func (*PublicDeployer) createTx(
+	unsignedTx txs.UnsignedTx,
+	wallet primary.Wallet,
+) (*txs.Tx, error) {
 	tx := txs.Tx{Unsigned: unsignedTx}
 	// sign with current wallet
 	if err := wallet.P().Signer().Sign(context.Background(), &tx); err != nil {
@@ -0,0 +1,368 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-network-runner/client"
+)
+
+const (
+	// the network runner stores snapshot <name> at <snapshotsDir>/anr-snapshot-<name>
+	SnapshotDirPrefix = "anr-snapshot-"
+
+	// written by the CLI inside the snapshot dir, the network runner ignores it
+	snapshotMetadataFileName = "avalanche-cli-snapshot.json"
+	// dir inside exported archives holding the VM binaries used by the snapshot
+	snapshotPluginsDirName = "avalanche-cli-plugins"
+)
+
+var (
+	ErrSnapshotNotFound    = errors.New("snapshot not found")
+	ErrSnapshotExists      = errors.New("snapshot already exists")
+	ErrInvalidSnapshotName = errors.New("snapshot names may only contain letters, digits, '.', '-' and '_', and may not start with '.'")
+
+	// no path separators, and no leading dot so that . and .. are rejected
+	snapshotNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-][a-zA-Z0-9._-]*$`)
+)
+
+// SnapshotChain is a custom chain running on the network when a snapshot was saved
+type SnapshotChain struct {
+	ChainName    string
+	BlockchainID string
+	SubnetID     string
+	VMID         string
+}
+
+type SnapshotMetadata struct {
+	Chains []SnapshotChain
+}
+
+type SnapshotInfo struct {
+	Name    string
+	Path    string
+	Size    int64
+	ModTime time.Time
+	// nil if the snapshot was not saved by the CLI
+	Metadata *SnapshotMetadata
+}
+
+// IsTemporary returns true for the snapshots saved by the CLI while restarting
+// the network, which are left behind to recover from failed restarts
+func (s SnapshotInfo) IsTemporary() bool {
+	return IsTemporarySnapshot(s.Name)
+}
+
+func IsTemporarySnapshot(name string) bool {
+	return strings.Contains(name, constants.TmpSnapshotInfix)
+}
+
+func ValidateSnapshotName(name string) error {
+	if !snapshotNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidSnapshotName, name)
+	}
+	return nil
+}
+
+// GetSnapshotPath returns the dir of snapshot [name], failing if the name is not
+// valid, so that the path always stays inside the snapshots dir
+func GetSnapshotPath(app *application.Avalanche, name string) (string, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return "", err
+	}
+	snapshotsDir := filepath.Clean(app.GetSnapshotsDir())
+	snapshotPath := filepath.Join(snapshotsDir, SnapshotDirPrefix+name)
+	if filepath.Dir(snapshotPath) != snapshotsDir {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSnapshotName, name)
+	}
+	return snapshotPath, nil
+}
+
+// SaveSnapshot saves the running network under [name], which stops it, recording
+// the custom chains it was running so they can be listed later
+func SaveSnapshot(ctx context.Context, app *application.Avalanche, cli client.Client, name string) error {
+	if err := ValidateSnapshotName(name); err != nil {
+		return err
+	}
+	// the network must be queried before saving, as saving stops it
+	status, err := cli.Status(ctx)
+	if err != nil {
+		return err
+	}
+	metadata := SnapshotMetadata{}
+	if status.GetClusterInfo() != nil {
+		for blockchainID, chainInfo := range status.ClusterInfo.CustomChains {
+			metadata.Chains = append(metadata.Chains, SnapshotChain{
+				ChainName:    chainInfo.ChainName,
+				BlockchainID: blockchainID,
+				SubnetID:     chainInfo.GetPchainId(),
+				VMID:         chainInfo.VmId,
+			})
+		}
+	}
+	sort.Slice(metadata.Chains, func(i, j int) bool {
+		return metadata.Chains[i].ChainName < metadata.Chains[j].ChainName
+	})
+	if _, err := cli.SaveSnapshot(ctx, name); err != nil {
+		return err
+	}
+	metadataBytes, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	snapshotPath, err := GetSnapshotPath(app, name)
+	if err != nil {
+		return err
+	}
+	metadataPath := filepath.Join(snapshotPath, snapshotMetadataFileName)
+	return os.WriteFile(metadataPath, metadataBytes, WriteReadReadPerms)
+}
+
+// GetSnapshots returns the snapshots of the current local network, sorted by name
+func GetSnapshots(app *application.Avalanche) ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(app.GetSnapshotsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	snapshots := []SnapshotInfo{}
+	for _, entry := range entries {
+		name := strings.TrimPrefix(entry.Name(), SnapshotDirPrefix)
+		// dirs with names the CLI can't address are not listed
+		if !entry.IsDir() || name == entry.Name() || ValidateSnapshotName(name) != nil {
+			continue
+		}
+		snapshot, err := GetSnapshot(app, name)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+func GetSnapshot(app *application.Avalanche, name string) (SnapshotInfo, error) {
+	snapshotPath, err := GetSnapshotPath(app, name)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	info, err := os.Stat(snapshotPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SnapshotInfo{}, fmt.Errorf("%w: %s", ErrSnapshotNotFound, name)
+		}
+		return SnapshotInfo{}, err
+	}
+	size, err := utils.DirSize(snapshotPath)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	snapshot := SnapshotInfo{
+		Name:    name,
+		Path:    snapshotPath,
+		Size:    size,
+		ModTime: info.ModTime(),
+	}
+	metadataBytes, err := os.ReadFile(filepath.Join(snapshotPath, snapshotMetadataFileName))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return SnapshotInfo{}, err
+	default:
+		var metadata SnapshotMetadata
+		if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+			return SnapshotInfo{}, fmt.Errorf("failed reading metadata of snapshot %s: %w", name, err)
+		}
+		snapshot.Metadata = &metadata
+	}
+	return snapshot, nil
+}
+
+// ExportSnapshot writes snapshot [name] as a tar.gz archive at [archivePath],
+// together with the VM binaries of its chains found at the plugins dir
+func ExportSnapshot(app *application.Avalanche, name string, archivePath string) error {
+	snapshot, err := GetSnapshot(app, name)
+	if err != nil {
+		return err
+	}
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	topDir := filepath.Base(snapshot.Path)
+	if err := addDirToTar(tarWriter, snapshot.Path, topDir); err != nil {
+		return err
+	}
+	if snapshot.Metadata != nil {
+		added := map[string]struct{}{}
+		for _, chain := range snapshot.Metadata.Chains {
+			if _, ok := added[chain.VMID]; ok {
+				continue
+			}
+			pluginPath := filepath.Join(app.GetPluginsDir(), chain.VMID)
+			if _, err := os.Stat(pluginPath); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			if err := addFileToTar(tarWriter, pluginPath, filepath.Join(topDir, snapshotPluginsDirName, chain.VMID)); err != nil {
+				return err
+			}
+			added[chain.VMID] = struct{}{}
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return archiveFile.Close()
+}
+
+// ImportSnapshot installs a snapshot archive written by ExportSnapshot into the
+// snapshots dir, under [name] if given, and installs the VM binaries it contains
+// that are missing from the plugins dir. Returns the name of the imported snapshot
+func ImportSnapshot(app *application.Avalanche, archivePath string, name string) (string, error) {
+	if name != "" {
+		if err := ValidateSnapshotName(name); err != nil {
+			return "", err
+		}
+	}
+	archiveBytes, err := os.ReadFile(archivePath)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(app.GetSnapshotsDir(), constants.DefaultPerms755); err != nil {
+		return "", err
+	}
+	extractDir, err := os.MkdirTemp(app.GetSnapshotsDir(), "import")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(extractDir)
+	if err := binutils.InstallArchive("tar.gz", archiveBytes, extractDir); err != nil {
+		return "", fmt.Errorf("failed extracting snapshot archive: %w", err)
+	}
+	entries, err := os.ReadDir(extractDir)
+	if err != nil {
+		return "", err
+	}
+	if len(entries) != 1 || !entries[0].IsDir() || !strings.HasPrefix(entries[0].Name(), SnapshotDirPrefix) {
+		return "", fmt.Errorf("%s is not a snapshot archive: expected a single %s<name> dir", archivePath, SnapshotDirPrefix)
+	}
+	if name == "" {
+		name = strings.TrimPrefix(entries[0].Name(), SnapshotDirPrefix)
+	}
+	snapshotPath, err := GetSnapshotPath(app, name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(snapshotPath); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrSnapshotExists, name)
+	}
+
+	extractedPath := filepath.Join(extractDir, entries[0].Name())
+	extractedPluginsDir := filepath.Join(extractedPath, snapshotPluginsDirName)
+	plugins, err := os.ReadDir(extractedPluginsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.MkdirAll(app.GetPluginsDir(), constants.DefaultPerms755); err != nil {
+		return "", err
+	}
+	for _, plugin := range plugins {
+		pluginPath := filepath.Join(app.GetPluginsDir(), plugin.Name())
+		if _, err := os.Stat(pluginPath); err == nil {
+			continue
+		}
+		if err := os.Rename(filepath.Join(extractedPluginsDir, plugin.Name()), pluginPath); err != nil {
+			return "", fmt.Errorf("failed installing VM binary %s: %w", plugin.Name(), err)
+		}
+		if err := os.Chmod(pluginPath, constants.DefaultPerms755); err != nil {
+			return "", err
+		}
+	}
+	if err := os.RemoveAll(extractedPluginsDir); err != nil {
+		return "", err
+	}
+	if err := os.Rename(extractedPath, snapshotPath); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func addDirToTar(tarWriter *tar.Writer, dir string, nameInTar string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(nameInTar, relPath)
+		if info.IsDir() {
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = name + "/"
+			return tarWriter.WriteHeader(header)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return addFileToTar(tarWriter, path, name)
+	})
+}
+
+func addFileToTar(tarWriter *tar.Writer, path string, nameInTar string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = nameInTar
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/config"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	require := setupTest(t)
+
+	app := &application.Avalanche{}
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	snapshotName := "test-snapshot"
+	snapshotPath, err := GetSnapshotPath(app, snapshotName)
+	require.NoError(err)
+	require.NoError(os.MkdirAll(filepath.Join(snapshotPath, "db", "node1"), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, "network.json"), []byte("{}"), WriteReadReadPerms))
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, "db", "node1", "data"), []byte("data"), WriteReadReadPerms))
+	metadata := SnapshotMetadata{Chains: []SnapshotChain{{ChainName: testChainName, VMID: testVMID}}}
+	metadataBytes, err := json.Marshal(metadata)
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, snapshotMetadataFileName), metadataBytes, WriteReadReadPerms))
+	require.NoError(os.MkdirAll(app.GetPluginsDir(), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(app.GetPluginsDir(), testVMID), []byte("vm"), constants.DefaultPerms755))
+
+	snapshot, err := GetSnapshot(app, snapshotName)
+	require.NoError(err)
+	require.Equal(metadata, *snapshot.Metadata)
+	require.Equal(int64(len("{}")+len("data")+len(metadataBytes)), snapshot.Size)
+	require.False(snapshot.IsTemporary())
+
+	archivePath := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+	require.NoError(ExportSnapshot(app, snapshotName, archivePath))
+
+	_, err = ImportSnapshot(app, archivePath, "")
+	require.ErrorIs(err, ErrSnapshotExists)
+	_, err = ImportSnapshot(app, archivePath, "../../subnets")
+	require.ErrorIs(err, ErrInvalidSnapshotName)
+
+	// import into another app, which lacks the VM binary
+	otherApp := &application.Avalanche{}
+	otherApp.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+	importedName, err := ImportSnapshot(otherApp, archivePath, "imported"+constants.TmpSnapshotInfix+"1")
+	require.NoError(err)
+
+	snapshots, err := GetSnapshots(otherApp)
+	require.NoError(err)
+	require.Len(snapshots, 1)
+	require.Equal(importedName, snapshots[0].Name)
+	require.True(snapshots[0].IsTemporary())
+	require.Equal(metadata, *snapshots[0].Metadata)
+	data, err := os.ReadFile(filepath.Join(snapshots[0].Path, "db", "node1", "data"))
+	require.NoError(err)
+	require.Equal("data", string(data))
+	vmBin, err := os.ReadFile(filepath.Join(otherApp.GetPluginsDir(), testVMID))
+	require.NoError(err)
+	require.Equal("vm", string(vmBin))
+}
+
+func TestGetSnapshotPath(t *testing.T) {
+	require := setupTest(t)
+
+	app := &application.Avalanche{}
+	app.Setup(t.TempDir(), logging.NoLog{}, config.New(), prompts.NewPrompter(), application.NewDownloader())
+
+	snapshotPath, err := GetSnapshotPath(app, "name_1.2-tmp-3")
+	require.NoError(err)
+	require.Equal(filepath.Join(app.GetSnapshotsDir(), SnapshotDirPrefix+"name_1.2-tmp-3"), snapshotPath)
+	for _, name := range []string{"", ".", "..", "../../subnets", "a/b", "/abs", ".hidden", "a\\b"} {
+		_, err := GetSnapshotPath(app, name)
+		require.ErrorIs(err, ErrInvalidSnapshotName, name)
+		_, err = GetSnapshot(app, name)
+		require.ErrorIs(err, ErrInvalidSnapshotName, name)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	stakingCertFileName = "staker.crt"
+	stakingKeyFileName  = "staker.key"
+
+	// avalanchego config keys, the content ones take precedence
+	// over the staking files generated by the network runner
+	stakingCertContentKey = "staking-tls-cert-file-content"
+	stakingKeyContentKey  = "staking-tls-key-file-content"
+)
+
+var (
+	errInvalidNodeFlag       = errors.New("node flags must have the form <nodeName>:<flag>=<value>")
+	errInvalidNodeConfigFlag = errors.New("node configs must have the form <nodeName>=<path to JSON file>")
+	errInvalidNodeConfig     = errors.New("invalid node config")
+	errUnknownNodeName       = errors.New("unknown node name")
+)
+
+// LocalNetworkTopology describes a fresh local network, started from a new genesis
+// instead of the bootstrap snapshot
+type LocalNetworkTopology struct {
+	NumNodes uint32
+	// avalanchego config of all nodes
+	GlobalConfig map[string]interface{}
+	// avalanchego config of specific nodes, by node name. Overrides the global config
+	NodeConfigs map[string]map[string]interface{}
+	// avalanchego flags for specific nodes, by node name. Override the node configs
+	NodeFlags map[string]map[string]interface{}
+	// dir with a <nodeName>/staker.crt and <nodeName>/staker.key pair for
+	// each node that should use a custom staking key
+	StakingKeysDir string
+}
+
+// NodeNames returns the names the network runner gives to the nodes
+func (t LocalNetworkTopology) NodeNames() []string {
+	names := make([]string, t.NumNodes)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%d", i+1)
+	}
+	return names
+}
+
+// CustomNodeConfigs returns the full avalanchego config of each node, by node name,
+// merging the global config, the node configs and the node flags.
+// A config is given for every node, as the network runner then ignores the
+// number of nodes
+func (t LocalNetworkTopology) CustomNodeConfigs() (map[string]string, error) {
+	nodeNames := t.NodeNames()
+	configs := map[string]map[string]interface{}{}
+	for _, nodeName := range nodeNames {
+		configs[nodeName] = map[string]interface{}{}
+		for k, v := range t.GlobalConfig {
+			configs[nodeName][k] = v
+		}
+	}
+
+	for _, nodeValues := range []struct {
+		desc   string
+		values map[string]map[string]interface{}
+	}{
+		{"node configs", t.NodeConfigs},
+		{"node flags", t.NodeFlags},
+	} {
+		for nodeName, values := range nodeValues.values {
+			config, ok := configs[nodeName]
+			if !ok {
+				return nil, fmt.Errorf("%w %q in %s, the network has nodes node1 to node%d", errUnknownNodeName, nodeName, nodeValues.desc, t.NumNodes)
+			}
+			for k, v := range values {
+				config[k] = v
+			}
+		}
+	}
+
+	if t.StakingKeysDir != "" {
+		for _, nodeName := range nodeNames {
+			cert, key, err := loadStakingKey(filepath.Join(t.StakingKeysDir, nodeName))
+			if err != nil {
+				return nil, err
+			}
+			if cert == nil {
+				continue
+			}
+			configs[nodeName][stakingCertContentKey] = base64.StdEncoding.EncodeToString(cert)
+			configs[nodeName][stakingKeyContentKey] = base64.StdEncoding.EncodeToString(key)
+		}
+	}
+
+	configStrs := map[string]string{}
+	for nodeName, config := range configs {
+		configBytes, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		configStrs[nodeName] = string(configBytes)
+	}
+	return configStrs, nil
+}
+
+// loads the staking cert and key at [nodeDir]. Returns nil if the
+// node has no custom staking key
+func loadStakingKey(nodeDir string) ([]byte, []byte, error) {
+	cert, err := os.ReadFile(filepath.Join(nodeDir, stakingCertFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := os.ReadFile(filepath.Join(nodeDir, stakingKeyFileName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("found staking cert at %s but failed reading its key: %w", nodeDir, err)
+	}
+	return cert, key, nil
+}
+
+// ParseNodeFlags parses flags of the form <nodeName>:<flag>=<value> into flag values
+// by node name. Values that are valid JSON (numbers, booleans) keep their type
+func ParseNodeFlags(nodeFlags []string) (map[string]map[string]interface{}, error) {
+	parsed := map[string]map[string]interface{}{}
+	for _, nodeFlag := range nodeFlags {
+		nodeName, flag, found := strings.Cut(nodeFlag, ":")
+		if !found || nodeName == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidNodeFlag, nodeFlag)
+		}
+		key, valueStr, found := strings.Cut(flag, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidNodeFlag, nodeFlag)
+		}
+		var value interface{}
+		if err := json.Unmarshal([]byte(valueStr), &value); err != nil {
+			value = valueStr
+		}
+		if _, ok := parsed[nodeName]; !ok {
+			parsed[nodeName] = map[string]interface{}{}
+		}
+		parsed[nodeName][key] = value
+	}
+	return parsed, nil
+}
+
+// LoadNodeConfigFiles parses flags of the form <nodeName>=<path>, loading the
+// avalanchego config of each node from the JSON file at <path>
+func LoadNodeConfigFiles(nodeConfigFlags []string) (map[string]map[string]interface{}, error) {
+	configs := map[string]map[string]interface{}{}
+	for _, nodeConfigFlag := range nodeConfigFlags {
+		nodeName, configPath, found := strings.Cut(nodeConfigFlag, "=")
+		if !found || nodeName == "" || configPath == "" {
+			return nil, fmt.Errorf("%w: %q", errInvalidNodeConfigFlag, nodeConfigFlag)
+		}
+		configBytes, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading config of node %s: %w", nodeName, err)
+		}
+		config, err := ParseNodeConfig(string(configBytes))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", configPath, err)
+		}
+		configs[nodeName] = config
+	}
+	return configs, nil
+}
+
+// ParseNodeConfig parses an avalanchego config, which must be a JSON object
+func ParseNodeConfig(configStr string) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if configStr == "" {
+		return config, nil
+	}
+	if err := json.Unmarshal([]byte(configStr), &config); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidNodeConfig, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package subnet
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNodeFlags(t *testing.T) {
+	require := require.New(t)
+
+	flags, err := ParseNodeFlags([]string{"node1:log-level=debug", "node1:http-port=9700", "node2:index-enabled=true"})
+	require.NoError(err)
+	require.Equal("debug", flags["node1"]["log-level"])
+	require.Equal(float64(9700), flags["node1"]["http-port"])
+	require.Equal(true, flags["node2"]["index-enabled"])
+
+	_, err = ParseNodeFlags([]string{"log-level=debug"})
+	require.ErrorIs(err, errInvalidNodeFlag)
+	_, err = ParseNodeFlags([]string{"node1:log-level"})
+	require.ErrorIs(err, errInvalidNodeFlag)
+}
+
+func TestCustomNodeConfigs(t *testing.T) {
+	require := require.New(t)
+
+	keysDir := t.TempDir()
+	node2Dir := filepath.Join(keysDir, "node2")
+	require.NoError(os.MkdirAll(node2Dir, constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(node2Dir, stakingCertFileName), []byte("cert"), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(node2Dir, stakingKeyFileName), []byte("key"), constants.DefaultPerms755))
+
+	topology := LocalNetworkTopology{
+		NumNodes:       3,
+		NodeFlags:      map[string]map[string]interface{}{"node1": {"log-level": "debug"}},
+		StakingKeysDir: keysDir,
+	}
+	configs, err := topology.CustomNodeConfigs()
+	require.NoError(err)
+	require.Len(configs, 3)
+	require.JSONEq(`{"log-level":"debug"}`, configs["node1"])
+	require.JSONEq(`{}`, configs["node3"])
+
+	var node2Config map[string]string
+	require.NoError(json.Unmarshal([]byte(configs["node2"]), &node2Config))
+	require.Equal(base64.StdEncoding.EncodeToString([]byte("cert")), node2Config[stakingCertContentKey])
+	require.Equal(base64.StdEncoding.EncodeToString([]byte("key")), node2Config[stakingKeyContentKey])
+
+	topology.NodeFlags = map[string]map[string]interface{}{"node4": {"log-level": "debug"}}
+	_, err = topology.CustomNodeConfigs()
+	require.ErrorIs(err, errUnknownNodeName)
+}
+
+func TestCustomNodeConfigsPrecedence(t *testing.T) {
+	require := require.New(t)
+
+	topology := LocalNetworkTopology{
+		NumNodes:     3,
+		GlobalConfig: map[string]interface{}{"log-level": "info", "index-enabled": true},
+		NodeConfigs: map[string]map[string]interface{}{
+			"node1": {"log-level": "debug", "http-host": "0.0.0.0"},
+			"node2": {"log-level": "warn"},
+		},
+		NodeFlags: map[string]map[string]interface{}{"node1": {"log-level": "verbo"}},
+	}
+	configs, err := topology.CustomNodeConfigs()
+	require.NoError(err)
+	require.JSONEq(`{"log-level":"verbo","http-host":"0.0.0.0","index-enabled":true}`, configs["node1"])
+	require.JSONEq(`{"log-level":"warn","index-enabled":true}`, configs["node2"])
+	require.JSONEq(`{"log-level":"info","index-enabled":true}`, configs["node3"])
+
+	topology.NodeConfigs = map[string]map[string]interface{}{"node9": {}}
+	_, err = topology.CustomNodeConfigs()
+	require.ErrorIs(err, errUnknownNodeName)
+}
+
+func TestLoadNodeConfigFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "node1.json")
+	require.NoError(os.WriteFile(validPath, []byte(`{"log-level":"debug"}`), WriteReadReadPerms))
+	invalidPath := filepath.Join(dir, "invalid.json")
+	require.NoError(os.WriteFile(invalidPath, []byte(`["log-level"]`), WriteReadReadPerms))
+
+	configs, err := LoadNodeConfigFiles([]string{"node1=" + validPath})
+	require.NoError(err)
+	require.Equal(map[string]map[string]interface{}{"node1": {"log-level": "debug"}}, configs)
+
+	_, err = LoadNodeConfigFiles([]string{"node1=" + invalidPath})
+	require.ErrorIs(err, errInvalidNodeConfig)
+	_, err = LoadNodeConfigFiles([]string{validPath})
+	require.ErrorIs(err, errInvalidNodeConfigFlag)
+}
@@ -5,11 +5,11 @@ package txutils
 import (
 	"fmt"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
-	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -20,21 +20,15 @@ import (
 //   - creates the string slice of required subnet auth addresses by applying
 //     the indices to the control keys slice
 //
-// expect tx.Unsigned type to be in [txs.AddSubnetValidatorTx, txs.CreateChainTx]
-func GetAuthSigners(tx *txs.Tx, network models.Network, subnetID ids.ID) ([]string, error) {
-	controlKeys, _, err := subnet.GetOwners(network, subnetID)
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func GetAuthSigners(app *application.Avalanche, tx *txs.Tx, network models.Network, subnetID ids.ID) ([]string, error) {
+	controlKeys, _, err := subnet.GetOwners(app, network, subnetID)
 	if err != nil {
 		return nil, err
 	}
-	unsignedTx := tx.Unsigned
-	var subnetAuth verify.Verifiable
-	switch unsignedTx := unsignedTx.(type) {
-	case *txs.AddSubnetValidatorTx:
-		subnetAuth = unsignedTx.SubnetAuth
-	case *txs.CreateChainTx:
-		subnetAuth = unsignedTx.SubnetAuth
-	default:
-		return nil, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	subnetAuth, err := GetSubnetAuth(tx)
+	if err != nil {
+		return nil, err
 	}
 	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
 	if !ok {
@@ -59,9 +53,9 @@ func GetAuthSigners(tx *txs.Tx, network models.Network, subnetID ids.ID) ([]stri
 //     authSigners by using the index) to the remaining signers list
 //
 // if the tx is fully signed, returns empty slice
-// expect tx.Unsigned type to be in [txs.AddSubnetValidatorTx, txs.CreateChainTx]
-func GetRemainingSigners(tx *txs.Tx, network models.Network, subnetID ids.ID) ([]string, error) {
-	authSigners, err := GetAuthSigners(tx, network, subnetID)
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func GetRemainingSigners(app *application.Avalanche, tx *txs.Tx, network models.Network, subnetID ids.ID) ([]string, error) {
+	authSigners, err := GetAuthSigners(app, tx, network, subnetID)
 	if err != nil {
 		return nil, err
 	}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
 // get network model associated to tx
-// expect tx.Unsigned type to be in [txs.AddSubnetValidatorTx, txs.CreateChainTx]
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
 func GetNetwork(tx *txs.Tx) (models.Network, error) {
 	unsignedTx := tx.Unsigned
 	var networkID uint32
@@ -19,6 +21,10 @@ func GetNetwork(tx *txs.Tx) (models.Network, error) {
 		networkID = unsignedTx.NetworkID
 	case *txs.CreateChainTx:
 		networkID = unsignedTx.NetworkID
+	case *txs.RemoveSubnetValidatorTx:
+		networkID = unsignedTx.NetworkID
+	case *txs.TransformSubnetTx:
+		networkID = unsignedTx.NetworkID
 	default:
 		return models.Undefined, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
 	}
@@ -29,6 +35,66 @@ func GetNetwork(tx *txs.Tx) (models.Network, error) {
 	return network, nil
 }
 
+// get subnet ID the tx operates on
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func GetSubnetID(tx *txs.Tx) (ids.ID, error) {
+	unsignedTx := tx.Unsigned
+	switch unsignedTx := unsignedTx.(type) {
+	case *txs.AddSubnetValidatorTx:
+		return unsignedTx.Validator.Subnet, nil
+	case *txs.CreateChainTx:
+		return unsignedTx.SubnetID, nil
+	case *txs.RemoveSubnetValidatorTx:
+		return unsignedTx.Subnet, nil
+	case *txs.TransformSubnetTx:
+		return unsignedTx.Subnet, nil
+	default:
+		return ids.Empty, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	}
+}
+
+// get the subnet auth field of the tx, that is, the part of the tx
+// that references the control keys that must sign it
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func GetSubnetAuth(tx *txs.Tx) (verify.Verifiable, error) {
+	unsignedTx := tx.Unsigned
+	switch unsignedTx := unsignedTx.(type) {
+	case *txs.AddSubnetValidatorTx:
+		return unsignedTx.SubnetAuth, nil
+	case *txs.CreateChainTx:
+		return unsignedTx.SubnetAuth, nil
+	case *txs.RemoveSubnetValidatorTx:
+		return unsignedTx.SubnetAuth, nil
+	case *txs.TransformSubnetTx:
+		return unsignedTx.SubnetAuth, nil
+	default:
+		return nil, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	}
+}
+
+// get a human readable name for the tx, to be used on user messages
+func GetTxName(tx *txs.Tx) string {
+	switch tx.Unsigned.(type) {
+	case *txs.AddSubnetValidatorTx:
+		return "Add Validator"
+	case *txs.CreateChainTx:
+		return "Blockchain Creation"
+	case *txs.RemoveSubnetValidatorTx:
+		return "Remove Validator"
+	case *txs.TransformSubnetTx:
+		return "Transform Subnet"
+	default:
+		return "Tx"
+	}
+}
+
+// returns true if the tx needs to be signed by the subnet control keys,
+// and so it can be passed around to be signed by multiple users
+func IsSubnetAuthTx(tx *txs.Tx) bool {
+	_, err := GetSubnetAuth(tx)
+	return err == nil
+}
+
 func IsCreateChainTx(tx *txs.Tx) bool {
 	_, ok := tx.Unsigned.(*txs.CreateChainTx)
 	return ok
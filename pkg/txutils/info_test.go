@@ -0,0 +1,108 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetAuthTxInfo(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: avago_constants.FujiID}}
+	subnetAuth := &secp256k1fx.Input{SigIndices: []uint32{0}}
+
+	tests := []struct {
+		name     string
+		unsigned txs.UnsignedTx
+		txName   string
+	}{
+		{
+			name: "create chain",
+			unsigned: &txs.CreateChainTx{
+				BaseTx:     baseTx,
+				SubnetID:   subnetID,
+				SubnetAuth: subnetAuth,
+			},
+			txName: "Blockchain Creation",
+		},
+		{
+			name: "add subnet validator",
+			unsigned: &txs.AddSubnetValidatorTx{
+				BaseTx: baseTx,
+				Validator: validator.SubnetValidator{
+					Validator: validator.Validator{NodeID: ids.GenerateTestNodeID()},
+					Subnet:    subnetID,
+				},
+				SubnetAuth: subnetAuth,
+			},
+			txName: "Add Validator",
+		},
+		{
+			name: "remove subnet validator",
+			unsigned: &txs.RemoveSubnetValidatorTx{
+				BaseTx:     baseTx,
+				NodeID:     ids.GenerateTestNodeID(),
+				Subnet:     subnetID,
+				SubnetAuth: subnetAuth,
+			},
+			txName: "Remove Validator",
+		},
+		{
+			name: "transform subnet",
+			unsigned: &txs.TransformSubnetTx{
+				BaseTx:     baseTx,
+				Subnet:     subnetID,
+				SubnetAuth: subnetAuth,
+			},
+			txName: "Transform Subnet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			tx := &txs.Tx{Unsigned: tt.unsigned}
+
+			require.True(IsSubnetAuthTx(tx))
+			require.Equal(tt.txName, GetTxName(tx))
+
+			network, err := GetNetwork(tx)
+			require.NoError(err)
+			require.Equal(models.Fuji, network)
+
+			txSubnetID, err := GetSubnetID(tx)
+			require.NoError(err)
+			require.Equal(subnetID, txSubnetID)
+
+			txSubnetAuth, err := GetSubnetAuth(tx)
+			require.NoError(err)
+			require.Equal(subnetAuth, txSubnetAuth)
+		})
+	}
+}
+
+func TestNonSubnetAuthTxInfo(t *testing.T) {
+	require := require.New(t)
+	tx := &txs.Tx{Unsigned: &txs.CreateSubnetTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: avago_constants.FujiID}},
+	}}
+
+	require.False(IsSubnetAuthTx(tx))
+	require.Equal("Tx", GetTxName(tx))
+
+	_, err := GetNetwork(tx)
+	require.Error(err)
+	_, err = GetSubnetID(tx)
+	require.Error(err)
+	_, err = GetSubnetAuth(tx)
+	require.Error(err)
+}
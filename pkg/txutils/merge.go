@@ -0,0 +1,88 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrNotEnoughTxsToMerge = errors.New("at least two txs are needed to merge")
+	ErrDifferentUnsignedTx = errors.New("txs to merge are not copies of the same unsigned tx")
+	ErrConflictingSigs     = errors.New("txs to merge contain different signatures for the same signer")
+)
+
+// merges the credentials of several copies of the same tx, that were signed
+// independently by different subnet auth keys, into a new tx
+//   - verifies that all txs share the same unsigned tx bytes
+//   - verifies that all txs have the same creds layout (number of creds and sigs)
+//   - for each sig position, takes the non empty sig found on any of the txs,
+//     failing if two txs have different non empty sigs for the same position
+//
+// the input txs are not modified
+func Merge(txsToMerge []*txs.Tx) (*txs.Tx, error) {
+	if len(txsToMerge) < 2 {
+		return nil, ErrNotEnoughTxsToMerge
+	}
+	baseTx := txsToMerge[0]
+	unsignedBytes := baseTx.Unsigned.Bytes()
+	for i, tx := range txsToMerge[1:] {
+		if !bytes.Equal(unsignedBytes, tx.Unsigned.Bytes()) {
+			return nil, fmt.Errorf("%w: tx %d differs from tx 0", ErrDifferentUnsignedTx, i+1)
+		}
+		if len(tx.Creds) != len(baseTx.Creds) {
+			return nil, fmt.Errorf("%w: tx %d has %d creds, tx 0 has %d",
+				ErrDifferentUnsignedTx,
+				i+1,
+				len(tx.Creds),
+				len(baseTx.Creds),
+			)
+		}
+	}
+	emptySig := [crypto.SECP256K1RSigLen]byte{}
+	mergedTx := txs.Tx{Unsigned: baseTx.Unsigned}
+	for credIndex := range baseTx.Creds {
+		baseCred, ok := baseTx.Creds[credIndex].(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", baseTx.Creds[credIndex])
+		}
+		mergedCred := &secp256k1fx.Credential{
+			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(baseCred.Sigs)),
+		}
+		for txIndex, tx := range txsToMerge {
+			cred, ok := tx.Creds[credIndex].(*secp256k1fx.Credential)
+			if !ok {
+				return nil, fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", tx.Creds[credIndex])
+			}
+			if len(cred.Sigs) != len(mergedCred.Sigs) {
+				return nil, fmt.Errorf("%w: cred %d of tx %d has %d sigs, tx 0 has %d",
+					ErrDifferentUnsignedTx,
+					credIndex,
+					txIndex,
+					len(cred.Sigs),
+					len(mergedCred.Sigs),
+				)
+			}
+			for sigIndex, sig := range cred.Sigs {
+				if sig == emptySig {
+					continue
+				}
+				if mergedCred.Sigs[sigIndex] != emptySig && mergedCred.Sigs[sigIndex] != sig {
+					return nil, fmt.Errorf("%w: sig %d of cred %d", ErrConflictingSigs, sigIndex, credIndex)
+				}
+				mergedCred.Sigs[sigIndex] = sig
+			}
+		}
+		mergedTx.Creds = append(mergedTx.Creds, mergedCred)
+	}
+	if err := mergedTx.Initialize(txs.Codec); err != nil {
+		return nil, fmt.Errorf("error initializing merged tx: %w", err)
+	}
+	return &mergedTx, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	emptyTestSig = [crypto.SECP256K1RSigLen]byte{}
+	testSigA     = [crypto.SECP256K1RSigLen]byte{1}
+	testSigB     = [crypto.SECP256K1RSigLen]byte{2}
+	testSigFee   = [crypto.SECP256K1RSigLen]byte{3}
+)
+
+func newTestTx(
+	t *testing.T,
+	unsigned txs.UnsignedTx,
+	subnetAuthSigs ...[crypto.SECP256K1RSigLen]byte,
+) *txs.Tx {
+	tx := &txs.Tx{
+		Unsigned: unsigned,
+		Creds: []verify.Verifiable{
+			&secp256k1fx.Credential{Sigs: [][crypto.SECP256K1RSigLen]byte{testSigFee}},
+			&secp256k1fx.Credential{Sigs: subnetAuthSigs},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func newTestCreateChainTx(subnetID ids.ID) *txs.CreateChainTx {
+	return &txs.CreateChainTx{
+		BaseTx:     txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: avago_constants.FujiID}},
+		SubnetID:   subnetID,
+		ChainName:  "test",
+		SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	require := require.New(t)
+	unsigned := newTestCreateChainTx(ids.GenerateTestID())
+
+	txA := newTestTx(t, unsigned, testSigA, emptyTestSig)
+	txB := newTestTx(t, unsigned, emptyTestSig, testSigB)
+
+	merged, err := Merge([]*txs.Tx{txA, txB})
+	require.NoError(err)
+	require.Len(merged.Creds, 2)
+	feeCred, ok := merged.Creds[0].(*secp256k1fx.Credential)
+	require.True(ok)
+	require.Equal([][crypto.SECP256K1RSigLen]byte{testSigFee}, feeCred.Sigs)
+	authCred, ok := merged.Creds[1].(*secp256k1fx.Credential)
+	require.True(ok)
+	require.Equal([][crypto.SECP256K1RSigLen]byte{testSigA, testSigB}, authCred.Sigs)
+
+	// inputs are left untouched
+	inputCred, ok := txA.Creds[1].(*secp256k1fx.Credential)
+	require.True(ok)
+	require.Equal(emptyTestSig, inputCred.Sigs[1])
+}
+
+func TestMergeErrors(t *testing.T) {
+	unsigned := newTestCreateChainTx(ids.GenerateTestID())
+	otherUnsigned := newTestCreateChainTx(ids.GenerateTestID())
+
+	tests := []struct {
+		name        string
+		txs         []*txs.Tx
+		expectedErr error
+	}{
+		{
+			name:        "single tx",
+			txs:         []*txs.Tx{newTestTx(t, unsigned, testSigA, emptyTestSig)},
+			expectedErr: ErrNotEnoughTxsToMerge,
+		},
+		{
+			name: "different unsigned tx",
+			txs: []*txs.Tx{
+				newTestTx(t, unsigned, testSigA, emptyTestSig),
+				newTestTx(t, otherUnsigned, emptyTestSig, testSigB),
+			},
+			expectedErr: ErrDifferentUnsignedTx,
+		},
+		{
+			name: "conflicting sigs",
+			txs: []*txs.Tx{
+				newTestTx(t, unsigned, testSigA, emptyTestSig),
+				newTestTx(t, unsigned, testSigB, emptyTestSig),
+			},
+			expectedErr: ErrConflictingSigs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Merge(tt.txs)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const utxosPageSize = 1024
+
+// StalenessReport describes the reasons why a tx can no longer be accepted
+// by the P-Chain
+type StalenessReport struct {
+	// IDs of the UTXOs consumed by the tx that are no longer available
+	SpentUTXOs []ids.ID
+	// reason why the tx subnet auth does not match current subnet owners,
+	// empty if it matches
+	SubnetAuthMismatch string
+}
+
+func (r *StalenessReport) IsStale() bool {
+	return len(r.SpentUTXOs) != 0 || r.SubnetAuthMismatch != ""
+}
+
+// checks if a tx is still valid against the current P-Chain state
+//   - all UTXOs consumed by the tx must still be unspent. UTXO owners are obtained
+//     by recovering the addresses that signed the fee creds
+//   - the subnet auth of the tx must match current subnet owners (GetOwners): the
+//     number of sig indices must equal the threshold, the indices must be in range,
+//     and the subnet auth sigs already present must come from the referenced control keys
+//
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func CheckStaleness(app *application.Avalanche, tx *txs.Tx, network models.Network, subnetID ids.ID) (*StalenessReport, error) {
+	api, err := app.GetAPIEndpoint(network)
+	if err != nil {
+		return nil, err
+	}
+	return checkStaleness(tx, network, subnetID, platformvm.NewClient(api))
+}
+
+func checkStaleness(tx *txs.Tx, network models.Network, subnetID ids.ID, pClient platformvm.Client) (*StalenessReport, error) {
+	report := &StalenessReport{}
+	spentUTXOs, err := getSpentUTXOs(tx, pClient)
+	if err != nil {
+		return nil, err
+	}
+	report.SpentUTXOs = spentUTXOs
+	report.SubnetAuthMismatch, err = getSubnetAuthMismatch(tx, network, subnetID, pClient)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// get the fee inputs of the tx
+// expect tx.Unsigned type to be a subnet auth tx (see IsSubnetAuthTx)
+func getInputs(tx *txs.Tx) ([]*avax.TransferableInput, error) {
+	unsignedTx := tx.Unsigned
+	switch unsignedTx := unsignedTx.(type) {
+	case *txs.AddSubnetValidatorTx:
+		return unsignedTx.Ins, nil
+	case *txs.CreateChainTx:
+		return unsignedTx.Ins, nil
+	case *txs.RemoveSubnetValidatorTx:
+		return unsignedTx.Ins, nil
+	case *txs.TransformSubnetTx:
+		return unsignedTx.Ins, nil
+	default:
+		return nil, fmt.Errorf("unexpected unsigned tx type %T", unsignedTx)
+	}
+}
+
+// recovers the address that generated [sig] for the tx
+func recoverSigner(tx *txs.Tx, sig [crypto.SECP256K1RSigLen]byte) (ids.ShortID, error) {
+	factory := crypto.FactorySECP256K1R{}
+	pubKey, err := factory.RecoverPublicKey(tx.Unsigned.Bytes(), sig[:])
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("couldn't recover signer of tx: %w", err)
+	}
+	return pubKey.Address(), nil
+}
+
+// returns the IDs of the UTXOs consumed by the tx that are not available
+// anymore on the P-Chain
+func getSpentUTXOs(tx *txs.Tx, pClient platformvm.Client) ([]ids.ID, error) {
+	inputs, err := getInputs(tx)
+	if err != nil {
+		return nil, err
+	}
+	// 1 fee cred for each input + 1 cred for subnet auth
+	if len(tx.Creds) != len(inputs)+1 {
+		return nil, fmt.Errorf("expected tx.Creds of len %d, got %d", len(inputs)+1, len(tx.Creds))
+	}
+	emptySig := [crypto.SECP256K1RSigLen]byte{}
+	owners := set.Set[ids.ShortID]{}
+	for i := range inputs {
+		cred, ok := tx.Creds[i].(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", tx.Creds[i])
+		}
+		if len(cred.Sigs) == 0 || cred.Sigs[0] == emptySig {
+			return nil, fmt.Errorf("expected funding sig 0 of cred %d to be filled", i)
+		}
+		owner, err := recoverSigner(tx, cred.Sigs[0])
+		if err != nil {
+			return nil, err
+		}
+		owners.Add(owner)
+	}
+	if owners.Len() == 0 {
+		return nil, nil
+	}
+	availableUTXOs, err := getUTXOIDs(pClient, owners.List())
+	if err != nil {
+		return nil, err
+	}
+	spentUTXOs := []ids.ID{}
+	for _, input := range inputs {
+		utxoID := input.InputID()
+		if !availableUTXOs.Contains(utxoID) {
+			spentUTXOs = append(spentUTXOs, utxoID)
+		}
+	}
+	return spentUTXOs, nil
+}
+
+// get the IDs of all P-Chain UTXOs owned by [addrs]
+func getUTXOIDs(pClient platformvm.Client, addrs []ids.ShortID) (set.Set[ids.ID], error) {
+	ctx := context.Background()
+	utxoIDs := set.Set[ids.ID]{}
+	startAddr := ids.ShortEmpty
+	startUTXOID := ids.Empty
+	for {
+		utxosBytes, endAddr, endUTXOID, err := pClient.GetUTXOs(ctx, addrs, utxosPageSize, startAddr, startUTXOID)
+		if err != nil {
+			return nil, fmt.Errorf("P-Chain UTXOs query error: %w", err)
+		}
+		for _, utxoBytes := range utxosBytes {
+			var utxo avax.UTXO
+			if _, err := txs.Codec.Unmarshal(utxoBytes, &utxo); err != nil {
+				return nil, fmt.Errorf("couldn't unmarshal utxo: %w", err)
+			}
+			utxoIDs.Add(utxo.InputID())
+		}
+		if len(utxosBytes) < utxosPageSize {
+			break
+		}
+		startAddr = endAddr
+		startUTXOID = endUTXOID
+	}
+	return utxoIDs, nil
+}
+
+// returns a description of the differences between the tx subnet auth and
+// the current subnet owners, or empty string if they match
+func getSubnetAuthMismatch(tx *txs.Tx, network models.Network, subnetID ids.ID, pClient platformvm.Client) (string, error) {
+	controlKeysStrs, threshold, err := subnet.GetOwnersFromClient(pClient, network, subnetID)
+	if err != nil {
+		return "", err
+	}
+	controlKeys, err := address.ParseToIDs(controlKeysStrs)
+	if err != nil {
+		return "", fmt.Errorf("failure parsing control keys: %w", err)
+	}
+	subnetAuth, err := GetSubnetAuth(tx)
+	if err != nil {
+		return "", err
+	}
+	subnetInput, ok := subnetAuth.(*secp256k1fx.Input)
+	if !ok {
+		return "", fmt.Errorf("expected subnetAuth of type *secp256k1fx.Input, got %T", subnetAuth)
+	}
+	if uint32(len(subnetInput.SigIndices)) != threshold {
+		return fmt.Sprintf("tx requires %d subnet auth signatures but current subnet threshold is %d",
+			len(subnetInput.SigIndices),
+			threshold,
+		), nil
+	}
+	for _, sigIndex := range subnetInput.SigIndices {
+		if sigIndex >= uint32(len(controlKeys)) {
+			return fmt.Sprintf("tx signer index %d exceeds current number of control keys %d",
+				sigIndex,
+				len(controlKeys),
+			), nil
+		}
+	}
+	if len(tx.Creds) == 0 {
+		return "", nil
+	}
+	cred, ok := tx.Creds[len(tx.Creds)-1].(*secp256k1fx.Credential)
+	if !ok {
+		return "", fmt.Errorf("expected cred to be of type *secp256k1fx.Credential, got %T", tx.Creds[len(tx.Creds)-1])
+	}
+	if len(cred.Sigs) != len(subnetInput.SigIndices) {
+		return "", fmt.Errorf("expected number of cred's signatures %d to equal number of auth signers %d",
+			len(cred.Sigs),
+			len(subnetInput.SigIndices),
+		)
+	}
+	emptySig := [crypto.SECP256K1RSigLen]byte{}
+	for i, sig := range cred.Sigs {
+		if sig == emptySig {
+			continue
+		}
+		signer, err := recoverSigner(tx, sig)
+		if err != nil {
+			return "", err
+		}
+		if signer != controlKeys[subnetInput.SigIndices[i]] {
+			return fmt.Sprintf("subnet auth signature %d was made by a key that is not the current control key %s",
+				i,
+				controlKeysStrs[subnetInput.SigIndices[i]],
+			), nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,279 @@
+// Copyright (C) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package txutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	avago_constants "github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverSigner(t *testing.T) {
+	require := require.New(t)
+	tx := newTestTx(t, newTestCreateChainTx(ids.GenerateTestID()), emptyTestSig, emptyTestSig)
+
+	factory := crypto.FactorySECP256K1R{}
+	privKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+	sigBytes, err := privKey.Sign(tx.Unsigned.Bytes())
+	require.NoError(err)
+	sig := [crypto.SECP256K1RSigLen]byte{}
+	copy(sig[:], sigBytes)
+
+	signer, err := recoverSigner(tx, sig)
+	require.NoError(err)
+	require.Equal(privKey.PublicKey().Address(), signer)
+}
+
+func TestStalenessReport(t *testing.T) {
+	require := require.New(t)
+	require.False((&StalenessReport{}).IsStale())
+	require.True((&StalenessReport{SpentUTXOs: []ids.ID{ids.GenerateTestID()}}).IsStale())
+	require.True((&StalenessReport{SubnetAuthMismatch: "threshold changed"}).IsStale())
+}
+
+func newTestKey(t *testing.T) crypto.PrivateKey {
+	factory := crypto.FactorySECP256K1R{}
+	key, err := factory.NewPrivateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func signTestTx(t *testing.T, tx *txs.Tx, key crypto.PrivateKey) [crypto.SECP256K1RSigLen]byte {
+	sigBytes, err := key.Sign(tx.Unsigned.Bytes())
+	require.NoError(t, err)
+	sig := [crypto.SECP256K1RSigLen]byte{}
+	copy(sig[:], sigBytes)
+	return sig
+}
+
+func newTestInput() *avax.TransferableInput {
+	return &avax.TransferableInput{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		In:     &secp256k1fx.TransferInput{Amt: 1, Input: secp256k1fx.Input{SigIndices: []uint32{0}}},
+	}
+}
+
+// returns the UTXO consumed by [input], as returned by the P-Chain API
+func newTestUTXOBytes(t *testing.T, input *avax.TransferableInput, owner ids.ShortID) []byte {
+	utxo := avax.UTXO{
+		UTXOID: input.UTXOID,
+		Asset:  input.Asset,
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{owner}},
+		},
+	}
+	utxoBytes, err := txs.Codec.Marshal(txs.Version, &utxo)
+	require.NoError(t, err)
+	return utxoBytes
+}
+
+// returns the create subnet tx giving the current owners of a subnet, as returned by the P-Chain API
+func newTestSubnetTxBytes(t *testing.T, controlKeys []ids.ShortID, threshold uint32) []byte {
+	tx := &txs.Tx{
+		Unsigned: &txs.CreateSubnetTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: avago_constants.FujiID}},
+			Owner:  &secp256k1fx.OutputOwners{Threshold: threshold, Addrs: controlKeys},
+		},
+	}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx.Bytes()
+}
+
+// returns a create chain tx consuming [inputs] with fee creds signed by [feeKey], and
+// subnet auth [sigIndices] with sigs made by [authKeys], left empty for nil keys
+func newTestStaleTx(
+	t *testing.T,
+	subnetID ids.ID,
+	inputs []*avax.TransferableInput,
+	feeKey crypto.PrivateKey,
+	sigIndices []uint32,
+	authKeys []crypto.PrivateKey,
+) *txs.Tx {
+	unsigned := newTestCreateChainTx(subnetID)
+	unsigned.Ins = inputs
+	unsigned.SubnetAuth = &secp256k1fx.Input{SigIndices: sigIndices}
+	tx := &txs.Tx{Unsigned: unsigned}
+	require.NoError(t, tx.Initialize(txs.Codec))
+	for range inputs {
+		tx.Creds = append(tx.Creds, &secp256k1fx.Credential{
+			Sigs: [][crypto.SECP256K1RSigLen]byte{signTestTx(t, tx, feeKey)},
+		})
+	}
+	authCred := &secp256k1fx.Credential{}
+	for _, authKey := range authKeys {
+		sig := emptyTestSig
+		if authKey != nil {
+			sig = signTestTx(t, tx, authKey)
+		}
+		authCred.Sigs = append(authCred.Sigs, sig)
+	}
+	tx.Creds = append(tx.Creds, authCred)
+	require.NoError(t, tx.Initialize(txs.Codec))
+	return tx
+}
+
+func TestGetSpentUTXOs(t *testing.T) {
+	feeKey := newTestKey(t)
+	feeAddr := feeKey.PublicKey().Address()
+	inputA := newTestInput()
+	inputB := newTestInput()
+	tx := newTestStaleTx(t, ids.GenerateTestID(), []*avax.TransferableInput{inputA, inputB}, feeKey, nil, nil)
+
+	tests := []struct {
+		name     string
+		utxos    [][]byte
+		utxosErr error
+		expected []ids.ID
+		err      error
+	}{
+		{
+			name:     "all available",
+			utxos:    [][]byte{newTestUTXOBytes(t, inputA, feeAddr), newTestUTXOBytes(t, inputB, feeAddr)},
+			expected: []ids.ID{},
+		},
+		{
+			name:     "one spent",
+			utxos:    [][]byte{newTestUTXOBytes(t, inputA, feeAddr)},
+			expected: []ids.ID{inputB.InputID()},
+		},
+		{
+			name:     "all spent",
+			utxos:    [][]byte{},
+			expected: []ids.ID{inputA.InputID(), inputB.InputID()},
+		},
+		{
+			name:     "query error",
+			utxosErr: errors.New("connection refused"),
+			err:      errors.New("P-Chain UTXOs query error: connection refused"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			pClient := &mocks.PClient{}
+			pClient.On("GetUTXOs", mock.Anything, []ids.ShortID{feeAddr}, mock.Anything, mock.Anything, mock.Anything).
+				Return(tt.utxos, ids.ShortEmpty, ids.Empty, tt.utxosErr)
+
+			spentUTXOs, err := getSpentUTXOs(tx, pClient)
+			if tt.err != nil {
+				require.EqualError(err, tt.err.Error())
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expected, spentUTXOs)
+		})
+	}
+}
+
+func TestGetSubnetAuthMismatch(t *testing.T) {
+	feeKey := newTestKey(t)
+	keyA, keyB, keyC := newTestKey(t), newTestKey(t), newTestKey(t)
+	addrA, addrB, addrC := keyA.PublicKey().Address(), keyB.PublicKey().Address(), keyC.PublicKey().Address()
+	subnetID := ids.GenerateTestID()
+
+	tests := []struct {
+		name        string
+		controlKeys []ids.ShortID
+		threshold   uint32
+		sigIndices  []uint32
+		authKeys    []crypto.PrivateKey
+		expected    string
+	}{
+		{
+			name:        "matching, partially signed",
+			controlKeys: []ids.ShortID{addrA, addrB, addrC},
+			threshold:   2,
+			sigIndices:  []uint32{0, 2},
+			authKeys:    []crypto.PrivateKey{keyA, nil},
+		},
+		{
+			name:        "matching, fully signed",
+			controlKeys: []ids.ShortID{addrA, addrB, addrC},
+			threshold:   2,
+			sigIndices:  []uint32{0, 2},
+			authKeys:    []crypto.PrivateKey{keyA, keyC},
+		},
+		{
+			name:        "threshold changed",
+			controlKeys: []ids.ShortID{addrA, addrB, addrC},
+			threshold:   3,
+			sigIndices:  []uint32{0, 2},
+			authKeys:    []crypto.PrivateKey{keyA, nil},
+			expected:    "tx requires 2 subnet auth signatures but current subnet threshold is 3",
+		},
+		{
+			name:        "control key removed",
+			controlKeys: []ids.ShortID{addrA, addrB},
+			threshold:   2,
+			sigIndices:  []uint32{0, 2},
+			authKeys:    []crypto.PrivateKey{keyA, nil},
+			expected:    "tx signer index 2 exceeds current number of control keys 2",
+		},
+		{
+			name:        "control key replaced",
+			controlKeys: []ids.ShortID{addrB, addrC},
+			threshold:   2,
+			sigIndices:  []uint32{0, 1},
+			authKeys:    []crypto.PrivateKey{keyA, nil},
+			expected:    "subnet auth signature 0 was made by a key that is not the current control key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			pClient := &mocks.PClient{}
+			pClient.On("GetTx", mock.Anything, subnetID).Return(newTestSubnetTxBytes(t, tt.controlKeys, tt.threshold), nil)
+			tx := newTestStaleTx(t, subnetID, []*avax.TransferableInput{newTestInput()}, feeKey, tt.sigIndices, tt.authKeys)
+
+			mismatch, err := getSubnetAuthMismatch(tx, models.Fuji, subnetID, pClient)
+			require.NoError(err)
+			if tt.expected == "" {
+				require.Empty(mismatch)
+				return
+			}
+			require.Contains(mismatch, tt.expected)
+		})
+	}
+}
+
+func TestCheckStaleness(t *testing.T) {
+	require := require.New(t)
+	feeKey := newTestKey(t)
+	authKey := newTestKey(t)
+	subnetID := ids.GenerateTestID()
+	input := newTestInput()
+	tx := newTestStaleTx(t, subnetID, []*avax.TransferableInput{input}, feeKey, []uint32{0}, []crypto.PrivateKey{nil})
+
+	pClient := &mocks.PClient{}
+	pClient.On("GetUTXOs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([][]byte{}, ids.ShortEmpty, ids.Empty, nil)
+	pClient.On("GetTx", mock.Anything, subnetID).
+		Return(newTestSubnetTxBytes(t, []ids.ShortID{authKey.PublicKey().Address()}, 1), nil)
+
+	report, err := checkStaleness(tx, models.Fuji, subnetID, pClient)
+	require.NoError(err)
+	require.True(report.IsStale())
+	require.Equal([]ids.ID{input.InputID()}, report.SpentUTXOs)
+	require.Empty(report.SubnetAuthMismatch)
+
+	// the subnet query failing is an error, not a staleness reason
+	pClient = &mocks.PClient{}
+	pClient.On("GetUTXOs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return([][]byte{newTestUTXOBytes(t, input, feeKey.PublicKey().Address())}, ids.ShortEmpty, ids.Empty, nil)
+	pClient.On("GetTx", mock.Anything, subnetID).Return(nil, errors.New("not found"))
+	_, err = checkStaleness(tx, models.Fuji, subnetID, pClient)
+	require.Error(err)
+}
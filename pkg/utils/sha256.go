@@ -26,6 +26,12 @@ func GetSHA256FromDisk(binPath string) (string, error) {
 	return hex.EncodeToString(hasher.Sum(nil)), nil
 }
 
+// GetSHA256 returns the hex encoded sha256 hash of [b]
+func GetSHA256(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
 func SearchSHA256File(file []byte, toSearch string) (string, error) {
 	lines := strings.Split(string(file), "\n")
 	for _, line := range lines {
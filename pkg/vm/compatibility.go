@@ -6,6 +6,7 @@ package vm
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strconv"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
@@ -15,7 +16,13 @@ import (
 	"golang.org/x/mod/semver"
 )
 
-var ErrNoAvagoVersion = errors.New("unable to find a compatible avalanchego version")
+var (
+	ErrNoAvagoVersion       = errors.New("unable to find a compatible avalanchego version")
+	ErrUnlistedAvagoVersion = errors.New("avalanchego version not found in the compatibility list")
+	ErrNoRPCVersion         = errors.New("no RPC version found")
+	ErrUnknownRPCVersion    = errors.New("unable to determine the RPC protocol version")
+	ErrRPCVersionMismatch   = errors.New("RPC protocol version mismatch")
+)
 
 func GetRPCProtocolVersion(app *application.Avalanche, vmType models.VMType, vmVersion string) (int, error) {
 	var url string
@@ -41,7 +48,7 @@ func GetRPCProtocolVersion(app *application.Avalanche, vmType models.VMType, vmV
 
 	version, ok := parsedCompat.RPCChainVMProtocolVersion[vmVersion]
 	if !ok {
-		return 0, errors.New("no RPC version found")
+		return 0, ErrNoRPCVersion
 	}
 
 	return version, nil
@@ -91,3 +98,75 @@ func GetLatestAvalancheGoByProtocolVersion(app *application.Avalanche, rpcVersio
 
 	return useVersion, nil
 }
+
+// GetAvalancheGoRPCProtocolVersion returns the RPC protocol version used by [avagoVersion]
+func GetAvalancheGoRPCProtocolVersion(app *application.Avalanche, avagoVersion string, url string) (int, error) {
+	compatibilityBytes, err := app.Downloader.Download(url)
+	if err != nil {
+		return 0, err
+	}
+
+	var parsedCompat models.AvagoCompatiblity
+	if err = json.Unmarshal(compatibilityBytes, &parsedCompat); err != nil {
+		return 0, err
+	}
+
+	for rpcVersion, versions := range parsedCompat {
+		for _, version := range versions {
+			if version == avagoVersion {
+				return strconv.Atoi(rpcVersion)
+			}
+		}
+	}
+	return 0, ErrUnlistedAvagoVersion
+}
+
+// GetLocalAvalancheGoVersion returns the version and RPC protocol version of the locally
+// built avalanchego at [avagoPath], checking they agree with the compatibility data
+func GetLocalAvalancheGoVersion(app *application.Avalanche, avagoPath string) (string, int, error) {
+	binaryVersion, err := binutils.GetBinaryVersion(avagoPath)
+	if err != nil {
+		return "", 0, err
+	}
+	rpcVersion, err := GetAvalancheGoRPCProtocolVersion(app, binaryVersion.Version, constants.AvalancheGoCompatibilityURL)
+	if err != nil && !errors.Is(err, ErrUnlistedAvagoVersion) {
+		return "", 0, err
+	}
+	return checkLocalBinaryRPCVersion(avagoPath, binaryVersion, rpcVersion, err == nil)
+}
+
+// GetLocalVMVersion returns the version and RPC protocol version of the locally built
+// [vmType] VM at [vmPath], checking they agree with the compatibility data
+func GetLocalVMVersion(app *application.Avalanche, vmType models.VMType, vmPath string) (string, int, error) {
+	binaryVersion, err := binutils.GetBinaryVersion(vmPath)
+	if err != nil {
+		return "", 0, err
+	}
+	rpcVersion, err := GetRPCProtocolVersion(app, vmType, binaryVersion.Version)
+	if err != nil && !errors.Is(err, ErrNoRPCVersion) {
+		return "", 0, err
+	}
+	return checkLocalBinaryRPCVersion(vmPath, binaryVersion, rpcVersion, err == nil)
+}
+
+// checks the RPC protocol version reported by the binary at [binPath] matches the one
+// of its version in the compatibility data, if [listed]. Versions not listed are
+// accepted with the RPC protocol version they report
+func checkLocalBinaryRPCVersion(
+	binPath string,
+	binaryVersion binutils.BinaryVersion,
+	rpcVersion int,
+	listed bool,
+) (string, int, error) {
+	switch {
+	case !listed && binaryVersion.RPCVersion == 0:
+		return "", 0, fmt.Errorf("%w: %s reports version %s, which is not in the compatibility data, and no RPC protocol version",
+			ErrUnknownRPCVersion, binPath, binaryVersion.Version)
+	case !listed:
+		return binaryVersion.Version, binaryVersion.RPCVersion, nil
+	case binaryVersion.RPCVersion != 0 && binaryVersion.RPCVersion != rpcVersion:
+		return "", 0, fmt.Errorf("%w: %s reports version %s with RPC protocol version %d, but that version uses %d",
+			ErrRPCVersionMismatch, binPath, binaryVersion.Version, binaryVersion.RPCVersion, rpcVersion)
+	}
+	return binaryVersion.Version, rpcVersion, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/internal/mocks"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/stretchr/testify/mock"
@@ -187,3 +188,103 @@ func TestGetLatestAvalancheGoByProtocolVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAvalancheGoRPCProtocolVersion(t *testing.T) {
+	tests := []struct {
+		name         string
+		avagoVersion string
+		expectedRPC  int
+		expectedErr  error
+	}{
+		{
+			name:         "only version",
+			avagoVersion: "v1.9.2",
+			expectedRPC:  19,
+		},
+		{
+			name:         "multiple versions",
+			avagoVersion: "v1.8.0",
+			expectedRPC:  17,
+		},
+		{
+			name:         "unlisted version",
+			avagoVersion: "v1.9.3",
+			expectedErr:  ErrUnlistedAvagoVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			mockDownloader := &mocks.Downloader{}
+			mockDownloader.On("Download", mock.Anything).Return(testAvagoCompat, nil)
+
+			app := application.New()
+			app.Downloader = mockDownloader
+
+			rpcVersion, err := GetAvalancheGoRPCProtocolVersion(app, tt.avagoVersion, constants.AvalancheGoCompatibilityURL)
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.expectedRPC, rpcVersion)
+		})
+	}
+}
+
+func TestCheckLocalBinaryRPCVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		binaryVersion binutils.BinaryVersion
+		rpcVersion    int
+		listed        bool
+		expectedRPC   int
+		expectedErr   error
+	}{
+		{
+			name:          "listed version",
+			binaryVersion: binutils.BinaryVersion{Version: "v1.9.2", RPCVersion: 19},
+			rpcVersion:    19,
+			listed:        true,
+			expectedRPC:   19,
+		},
+		{
+			name:          "listed version not reporting rpc",
+			binaryVersion: binutils.BinaryVersion{Version: "v1.9.2"},
+			rpcVersion:    19,
+			listed:        true,
+			expectedRPC:   19,
+		},
+		{
+			name:          "listed version reporting another rpc",
+			binaryVersion: binutils.BinaryVersion{Version: "v1.9.2", RPCVersion: 20},
+			rpcVersion:    19,
+			listed:        true,
+			expectedErr:   ErrRPCVersionMismatch,
+		},
+		{
+			name:          "unlisted version",
+			binaryVersion: binutils.BinaryVersion{Version: "v1.9.3", RPCVersion: 20},
+			expectedRPC:   20,
+		},
+		{
+			name:          "unlisted version not reporting rpc",
+			binaryVersion: binutils.BinaryVersion{Version: "v1.9.3"},
+			expectedErr:   ErrUnknownRPCVersion,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			version, rpcVersion, err := checkLocalBinaryRPCVersion("bin", tt.binaryVersion, tt.rpcVersion, tt.listed)
+			if tt.expectedErr != nil {
+				require.ErrorIs(err, tt.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Equal(tt.binaryVersion.Version, version)
+			require.Equal(tt.expectedRPC, rpcVersion)
+		})
+	}
+}
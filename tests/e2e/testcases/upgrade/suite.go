@@ -69,7 +69,7 @@ var _ = ginkgo.Describe("[Upgrade expect network failure]", ginkgo.Ordered, func
 			Subnet:   subnetName,
 			Networks: make(map[string]models.NetworkData),
 		}
-		sc.Networks[models.Local.String()] = models.NetworkData{
+		sc.Networks[app.GetNetworkKey(models.Local)] = models.NetworkData{
 			SubnetID:     ids.GenerateTestID(),
 			BlockchainID: ids.GenerateTestID(),
 		}
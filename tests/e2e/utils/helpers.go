@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/key"
@@ -53,6 +54,13 @@ func GetBaseDir() string {
 	return path.Join(usr.HomeDir, baseDir)
 }
 
+// returns an app for the CLI base dir, to reach the selected local network
+func getApp() *application.Avalanche {
+	app := application.New()
+	app.Setup(GetBaseDir(), logging.NoLog{}, nil, nil, nil)
+	return app
+}
+
 func GetAPMDir() string {
 	usr, err := user.Current()
 	if err != nil {
@@ -495,7 +503,7 @@ func ParsePublicDeployOutput(output string) (string, string, error) {
 }
 
 func RestartNodesWithWhitelistedSubnets(whitelistedSubnets string) error {
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(getApp())
 	if err != nil {
 		return err
 	}
@@ -551,7 +559,7 @@ func GetNodeVMVersion(nodeURI string, vmid string) (string, error) {
 }
 
 func GetNodesInfo() (map[string]NodeInfo, error) {
-	cli, err := binutils.NewGRPCClient()
+	cli, err := binutils.NewGRPCClient(getApp())
 	if err != nil {
 		return nil, err
 	}